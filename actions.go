@@ -2,6 +2,7 @@ package jobber
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -23,12 +24,57 @@ const (
 	TemplatedResource PipelineActionType = iota
 	ValuesTransform
 	Executable
+
+	// Wait waits for existing resources to meet a definition (see ResourceWaitDefinition).
+	Wait
+
+	// PodExec runs a command in a container (see PodExecDefinition).
+	PodExec
+
+	// CopyFromPod copies files out of a container (see PodCopyDefinition).
+	CopyFromPod
+
+	// Patch modifies an existing object, which is restored at cleanup (see ResourcePatchDefinition).
+	Patch
+
+	// ParallelGroup is a group of actions that the Runner runs at the same time.
+	ParallelGroup
 )
 
 type PipelineAction struct {
 	Type                     PipelineActionType
 	Descriptor               string
 	ActionFullyQualifiedPath string
+
+	// Name is empty if the action was not given one, in which case its assets are named for its target.
+	Name string
+
+	// DependsOn names the actions that must complete first in a dependency graph.  Resources are deleted in decreasing
+	// order of dependencyDepth.
+	DependsOn       []string
+	dependencyDepth int
+
+	// Env and Args are given to an executable or values-transform when it is run.
+	Env  map[string]string
+	Args []string
+
+	// RetryPolicy is nil if the action is not retried when it fails.
+	RetryPolicy *ActionRetryPolicy
+
+	// Timeouts is nil if the action is not limited and the default wait limits apply.
+	Timeouts *ActionTimeouts
+
+	// Follow causes a resources action to report the logs of the Pods of each Job while it waits for the Job.
+	Follow bool
+
+	// Mode determines how a resources action submits each resource.  If it is empty, resources are created.
+	Mode ResourceMode
+
+	// Condition is nil if the action always runs.
+	Condition *ActionCondition
+
+	// Members is set only if Type is ParallelGroup.
+	Members []*PipelineAction
 }
 
 type PipelineActionOutcome struct {
@@ -41,7 +87,19 @@ type PipelineActionOutcome struct {
 
 type PipelineExecutionEnvironment struct {
 	EnvironmentalVariables map[string]string
-	flattedString          []string
+
+	// DryRun causes resources to be submitted with server-side dry run, and other actions to be skipped.
+	DryRun bool
+
+	// ValuesTransformsInDryRun, when true along with DryRun, causes values-transforms to be run rather than skipped.
+	ValuesTransformsInDryRun bool
+
+	// FollowJobLogs, when true, causes every resources action to behave as if its Follow were set.
+	FollowJobLogs bool
+
+	// RetrievedAssetsDirectoryPath is where copy-from-pod actions copy files, regardless of the Context values.
+	RetrievedAssetsDirectoryPath string
+	flattedString                []string
 }
 
 func (e *PipelineExecutionEnvironment) ToFlattenedStrings() []string {
@@ -96,6 +154,30 @@ func PipelineActionFromStringDescriptor(descriptor string, pipelineActionBasePat
 			Descriptor:               descriptor,
 			ActionFullyQualifiedPath: actionFullyQualifiedPath,
 		}, nil
+	case "waits":
+		return &PipelineAction{
+			Type:                     Wait,
+			Descriptor:               descriptor,
+			ActionFullyQualifiedPath: actionFullyQualifiedPath,
+		}, nil
+	case "pod-exec":
+		return &PipelineAction{
+			Type:                     PodExec,
+			Descriptor:               descriptor,
+			ActionFullyQualifiedPath: actionFullyQualifiedPath,
+		}, nil
+	case "copy-from-pod":
+		return &PipelineAction{
+			Type:                     CopyFromPod,
+			Descriptor:               descriptor,
+			ActionFullyQualifiedPath: actionFullyQualifiedPath,
+		}, nil
+	case "patch":
+		return &PipelineAction{
+			Type:                     Patch,
+			Descriptor:               descriptor,
+			ActionFullyQualifiedPath: actionFullyQualifiedPath,
+		}, nil
 	default:
 		return nil, fmt.Errorf("pipeline type (%s) is not valid", pathElements[0])
 	}
@@ -110,34 +192,104 @@ const (
 	PodMovedToRunningState
 	ExecutionSuccessful
 	ValuesTransformCompleted
+	ResourceAcceptedInDryRun
+	ResourceRejectedInDryRun
+	ActionSkippedInDryRun
 	ActionCompletedSuccessfully
 	AnErrorOccurred
+	ResourceWaitCompleted
+	FilesCopiedFromPod
+	ContainerLogLineRead
+	ResourcePatched
+	ResourceApplied
+	ResourceReady
 )
 
 type ActionEvent struct {
 	Type                   ActionEventType
 	Error                  error
 	ExpandedTemplateBuffer *bytes.Buffer
+	StdinBuffer            *bytes.Buffer
 	StdoutBuffer           *bytes.Buffer
 	StderrBuffer           *bytes.Buffer
 	AffectedResource       *GenericK8sResource
+
+	// Description describes what was waited for, or what was copied.  It is set only when the event type is
+	// ResourceWaitCompleted or FilesCopiedFromPod.
+	Description string
+
+	// CopiedFiles are the files, relative to the retrieved assets directory, copied by a copy-from-pod action.
+	CopiedFiles []string
+
+	// LogLine is set only when the event type is ContainerLogLineRead.
+	LogLine *ContainerLogLine
+
+	// PatchedResource is set only when the event type is ResourcePatched or ResourceApplied.
+	PatchedResource *PatchedK8sResource
 }
 
-func (action *PipelineAction) Run(pipelineVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment, client *Client, eventChannel chan<- *ActionEvent) {
+// Run performs the action, sending events to eventChannel until ActionCompletedSuccessfully or AnErrorOccurred.  If
+// ctx is cancelled, the action is abandoned.
+func (action *PipelineAction) Run(ctx context.Context, pipelineVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment, client *Client, eventChannel chan<- *ActionEvent) {
+	if executionEnvironment.DryRun && action.Type != TemplatedResource && !(action.Type == ValuesTransform && executionEnvironment.ValuesTransformsInDryRun) {
+		eventChannel <- &ActionEvent{
+			Type: ActionSkippedInDryRun,
+		}
+		eventChannel <- &ActionEvent{
+			Type: ActionCompletedSuccessfully,
+		}
+		return
+	}
+
+	if action.Type == ParallelGroup {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: fmt.Errorf("a parallel group cannot be run as a single action"),
+		}
+		return
+	}
+
+	ctx, cancel := action.Timeouts.withActionTimeout(ctx)
+	defer cancel()
+
 	switch action.Type {
 	case TemplatedResource:
-		action.runTemplatedResource(pipelineVariables, client, eventChannel)
+		action.runTemplatedResource(ctx, pipelineVariables, executionEnvironment.DryRun, action.Follow || executionEnvironment.FollowJobLogs, client, eventChannel)
 	case Executable:
-		action.runExecutable(pipelineVariables, executionEnvironment, eventChannel)
+		action.runExecutable(ctx, pipelineVariables, executionEnvironment, eventChannel)
 	case ValuesTransform:
-		action.runValuesTransform(pipelineVariables, eventChannel)
+		action.runValuesTransform(ctx, pipelineVariables, executionEnvironment, eventChannel)
+	case Wait:
+		action.runWait(ctx, pipelineVariables, client, eventChannel)
+	case PodExec:
+		action.runPodExec(ctx, pipelineVariables, client, eventChannel)
+	case CopyFromPod:
+		action.runCopyFromPod(ctx, pipelineVariables, executionEnvironment.RetrievedAssetsDirectoryPath, client, eventChannel)
+	case Patch:
+		action.runPatch(ctx, pipelineVariables, client, eventChannel)
 	}
 }
 
+// expandDefinitionTemplate expands the action target, which is a definition file (rather than a resources
+// template), with pipelineVariables.
+func (action *PipelineAction) expandDefinitionTemplate(pipelineVariables *PipelineVariables) (*bytes.Buffer, error) {
+	tmpl, err := template.New(filepath.Base(action.ActionFullyQualifiedPath)).Funcs(sprig.FuncMap()).Funcs(JobberTemplateFunctions()).ParseFiles(action.ActionFullyQualifiedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read definition template (%s): %s", action.ActionFullyQualifiedPath, err)
+	}
+
+	templateBuffer := new(bytes.Buffer)
+	if err = tmpl.Execute(templateBuffer, pipelineVariables); err != nil {
+		return nil, fmt.Errorf("failed to expand definition template (%s): %s", action.ActionFullyQualifiedPath, err)
+	}
+
+	return templateBuffer, nil
+}
+
 var yamlDocumentSplitPattern = regexp.MustCompile(`(?m)^---$`)
 var emptyYamlDocumentMatch = regexp.MustCompile(`(?s)^\s*$`)
 
-func (action *PipelineAction) runTemplatedResource(pipelineVariables *PipelineVariables, client *Client, eventChannel chan<- *ActionEvent) {
+func (action *PipelineAction) runTemplatedResource(ctx context.Context, pipelineVariables *PipelineVariables, dryRun bool, followJobLogs bool, client *Client, eventChannel chan<- *ActionEvent) {
 	tmpl, err := template.New(filepath.Base(action.ActionFullyQualifiedPath)).Funcs(sprig.FuncMap()).Funcs(JobberTemplateFunctions()).ParseFiles(action.ActionFullyQualifiedPath)
 	if err != nil {
 		eventChannel <- &ActionEvent{
@@ -171,6 +323,8 @@ func (action *PipelineAction) runTemplatedResource(pipelineVariables *PipelineVa
 		}
 	}
 
+	rejectedInDryRun := &RejectedInDryRunError{}
+
 	for _, yamlDocumentString := range yamlDocumentsThatAreNotEmpty {
 		decoder := yaml.NewDecoder(strings.NewReader(yamlDocumentString))
 		decodedYaml := make(map[string]any)
@@ -193,32 +347,132 @@ func (action *PipelineAction) runTemplatedResource(pipelineVariables *PipelineVa
 				return
 			}
 
-			if resource.NamespaceName() == "" {
+			if resource.IsNamespaced() && resource.NamespaceName() == "" {
 				resource.SetNamespace(pipelineVariables.Runtime.DefaultNamespace.Name)
 			}
 
-			if err := resource.Create(); err != nil {
+			mode, err := ResourceModeFor(resource.ApiObject(), action.Mode)
+			if err == nil && mode == ResourceModeApply && resource.Name == "" {
+				err = fmt.Errorf("a resource in Apply mode must have a metadata.name")
+			}
+			if err != nil {
 				eventChannel <- &ActionEvent{
 					Type:  AnErrorOccurred,
-					Error: fmt.Errorf("failed to create resource: %s", err),
+					Error: fmt.Errorf("decoded yaml from template (%s) cannot be submitted: %s", action.ActionFullyQualifiedPath, err),
 				}
 				return
 			}
 
-			eventChannel <- &ActionEvent{
-				Type:             ResourceCreated,
-				AffectedResource: resource,
+			waitTimeout, probeInterval, err := action.Timeouts.waitLimitsFor(resource)
+			if err != nil {
+				eventChannel <- &ActionEvent{
+					Type:  AnErrorOccurred,
+					Error: err,
+				}
+				return
+			}
+
+			awaitsReadinessPerAnnotation, readinessIsAnnotated, err := readinessAnnotationOf(resource.ApiObject())
+			if err != nil {
+				eventChannel <- &ActionEvent{
+					Type:  AnErrorOccurred,
+					Error: err,
+				}
+				return
+			}
+
+			withoutJobberAnnotations(resource.ApiObject())
+
+			if dryRun {
+				submitInDryRun := resource.CreateInDryRun
+				if mode == ResourceModeApply {
+					submitInDryRun = resource.ApplyInDryRun
+				}
+
+				if err := submitInDryRun(ctx); err != nil {
+					if ctx.Err() != nil {
+						eventChannel <- &ActionEvent{
+							Type:             AnErrorOccurred,
+							Error:            fmt.Errorf("not accepted in dry run: %w", explainedIfActionTimedOut(ctx, err)),
+							AffectedResource: resource,
+						}
+						return
+					}
+
+					err = fmt.Errorf("resource kind (%s) named (%s) not accepted in dry run: %w", resource.Kind, resource.Name, err)
+					rejectedInDryRun.Rejections = append(rejectedInDryRun.Rejections, err)
+
+					eventChannel <- &ActionEvent{
+						Type:             ResourceRejectedInDryRun,
+						Error:            err,
+						AffectedResource: resource,
+					}
+					continue
+				}
+
+				eventChannel <- &ActionEvent{
+					Type:             ResourceAcceptedInDryRun,
+					AffectedResource: resource,
+				}
+
+				pipelineVariables.Runtime.Add(resource)
+				continue
+			}
+
+			if mode == ResourceModeApply {
+				appliedConfiguration := resource.ApiObject().DeepCopy()
+
+				existingObject, err := resource.Apply(ctx)
+				if err != nil {
+					eventChannel <- &ActionEvent{
+						Type:  AnErrorOccurred,
+						Error: fmt.Errorf("failed to apply resource: %w", explainedIfActionTimedOut(ctx, err)),
+					}
+					return
+				}
+
+				if existingObject == nil {
+					eventChannel <- &ActionEvent{
+						Type:             ResourceCreated,
+						AffectedResource: resource,
+					}
+				} else {
+					eventChannel <- &ActionEvent{
+						Type:             ResourceApplied,
+						AffectedResource: resource,
+						PatchedResource: &PatchedK8sResource{
+							information:          resource.Information(),
+							groupVersionResource: resource.groupVersionResource,
+							original:             existingObject,
+							appliedConfiguration: appliedConfiguration,
+							client:               client,
+						},
+					}
+				}
+			} else {
+				if err := resource.Create(ctx); err != nil {
+					eventChannel <- &ActionEvent{
+						Type:  AnErrorOccurred,
+						Error: fmt.Errorf("failed to create resource: %w", explainedIfActionTimedOut(ctx, err)),
+					}
+					return
+				}
+
+				eventChannel <- &ActionEvent{
+					Type:             ResourceCreated,
+					AffectedResource: resource,
+				}
 			}
 
 			switch resource.GvkString() {
 			case "v1/Pod":
-				if err = resource.AsAPod().WaitForRunningState(60 * time.Second); err != nil {
+				if err = resource.AsAPod().WaitForRunningState(ctx, firstNonZeroDuration(waitTimeout, defaultPodRunningStateTimeout), firstNonZeroDuration(probeInterval, defaultPodRunningStateProbeInterval)); err != nil {
 					if err == ErrorTimeExceeded {
-						err = fmt.Errorf("timed out waiting for Running state")
+						err = fmt.Errorf("%w: Pod did not reach Running state within %s", ErrorTimeExceeded, firstNonZeroDuration(waitTimeout, defaultPodRunningStateTimeout))
 					}
 					eventChannel <- &ActionEvent{
 						Type:             AnErrorOccurred,
-						Error:            err,
+						Error:            explainedIfActionTimedOut(ctx, err),
 						AffectedResource: resource,
 					}
 					return
@@ -229,62 +483,116 @@ func (action *PipelineAction) runTemplatedResource(pipelineVariables *PipelineVa
 					AffectedResource: resource,
 				}
 			case "batch/v1/Job":
-				if err = resource.AsAJob().WaitForCompletion(); err != nil {
+				stopFollowingJobLogs := func() {}
+				if followJobLogs {
+					stopFollowingJobLogs = startFollowingJobLogs(ctx, resource, firstNonZeroDuration(probeInterval, defaultJobCompletionProbeInterval), client, eventChannel)
+				}
+
+				err = resource.AsAJob().WaitForCompletion(ctx, waitTimeout, firstNonZeroDuration(probeInterval, defaultJobCompletionProbeInterval))
+				stopFollowingJobLogs()
+
+				if err != nil {
+					if err == ErrorTimeExceeded {
+						err = fmt.Errorf("%w: Job did not complete within %s", ErrorTimeExceeded, waitTimeout)
+					}
 					eventChannel <- &ActionEvent{
 						Type:             AnErrorOccurred,
-						Error:            err,
+						Error:            explainedIfActionTimedOut(ctx, err),
+						AffectedResource: resource,
+					}
+					return
+				}
+			default:
+				waitsForReadiness := awaitsReadinessPerAnnotation
+				if !readinessIsAnnotated {
+					waitsForReadiness, err = awaitsReadiness(ctx, resource, firstNonZeroDuration(probeInterval, defaultReadinessProbeInterval))
+				}
+				if err != nil {
+					eventChannel <- &ActionEvent{
+						Type:             AnErrorOccurred,
+						Error:            explainedIfActionTimedOut(ctx, err),
+						AffectedResource: resource,
+					}
+					return
+				}
+
+				if !waitsForReadiness {
+					break
+				}
+
+				if err = resource.WaitForReadiness(ctx, firstNonZeroDuration(waitTimeout, defaultReadinessTimeout), firstNonZeroDuration(probeInterval, defaultReadinessProbeInterval)); err != nil {
+					eventChannel <- &ActionEvent{
+						Type:             AnErrorOccurred,
+						Error:            explainedIfActionTimedOut(ctx, err),
 						AffectedResource: resource,
 					}
 					return
 				}
+
+				eventChannel <- &ActionEvent{
+					Type:             ResourceReady,
+					AffectedResource: resource,
+				}
 			}
 
 			pipelineVariables.Runtime.Add(resource)
 		}
 	}
 
+	if len(rejectedInDryRun.Rejections) > 0 {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: rejectedInDryRun,
+		}
+		return
+	}
+
 	eventChannel <- &ActionEvent{
 		Type: ActionCompletedSuccessfully,
 	}
 }
 
-func (action *PipelineAction) runExecutable(pipelineVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment, eventChannel chan<- *ActionEvent) {
-	cmdStdout := new(bytes.Buffer)
-	cmdStderr := new(bytes.Buffer)
-
-	cmd := exec.Command(action.ActionFullyQualifiedPath)
-	cmd.Stdout = cmdStdout
-	cmd.Stderr = cmdStderr
-
-	cmd.Env = executionEnvironment.ToFlattenedStrings()
-
-	jsonBytes, err := json.Marshal(pipelineVariables)
+func (action *PipelineAction) runExecutable(ctx context.Context, pipelineVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment, eventChannel chan<- *ActionEvent) {
+	_, cmdStdout, cmdStderr, err := action.runCommandWithVariablesOnStdin(ctx, pipelineVariables, executionEnvironment)
 	if err != nil {
 		eventChannel <- &ActionEvent{
-			Type:  AnErrorOccurred,
-			Error: fmt.Errorf("failed to marshall variables to json: %s", err),
+			Type:         AnErrorOccurred,
+			Error:        err,
+			StdoutBuffer: cmdStdout,
+			StderrBuffer: cmdStderr,
 		}
 		return
 	}
 
-	stdinWritePipe, err := cmd.StdinPipe()
+	eventChannel <- &ActionEvent{
+		Type:         ExecutionSuccessful,
+		StdoutBuffer: cmdStdout,
+		StderrBuffer: cmdStderr,
+	}
+
+	eventChannel <- &ActionEvent{
+		Type: ActionCompletedSuccessfully,
+	}
+}
+
+func (action *PipelineAction) runValuesTransform(ctx context.Context, pipelineVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment, eventChannel chan<- *ActionEvent) {
+	cmdStdin, cmdStdout, cmdStderr, err := action.runCommandWithVariablesOnStdin(ctx, pipelineVariables, executionEnvironment)
 	if err != nil {
 		eventChannel <- &ActionEvent{
-			Type:  AnErrorOccurred,
-			Error: fmt.Errorf("could not connect stdin pipe: %s", err),
+			Type:         AnErrorOccurred,
+			Error:        err,
+			StdinBuffer:  cmdStdin,
+			StdoutBuffer: cmdStdout,
+			StderrBuffer: cmdStderr,
 		}
 		return
 	}
 
-	go func() {
-		defer stdinWritePipe.Close()
-		stdinWritePipe.Write(jsonBytes)
-	}()
-
-	if err := cmd.Run(); err != nil {
+	if err := pipelineVariables.ReplaceValuesAndContextFromJson(cmdStdout.Bytes()); err != nil {
 		eventChannel <- &ActionEvent{
 			Type:         AnErrorOccurred,
-			Error:        err,
+			Error:        fmt.Errorf("failed to process transformed values: %s", err),
+			StdinBuffer:  cmdStdin,
 			StdoutBuffer: cmdStdout,
 			StderrBuffer: cmdStderr,
 		}
@@ -292,7 +600,8 @@ func (action *PipelineAction) runExecutable(pipelineVariables *PipelineVariables
 	}
 
 	eventChannel <- &ActionEvent{
-		Type:         ExecutionSuccessful,
+		Type:         ValuesTransformCompleted,
+		StdinBuffer:  cmdStdin,
 		StdoutBuffer: cmdStdout,
 		StderrBuffer: cmdStderr,
 	}
@@ -302,7 +611,72 @@ func (action *PipelineAction) runExecutable(pipelineVariables *PipelineVariables
 	}
 }
 
-func (action *PipelineAction) runValuesTransform(pipelineVariables *PipelineVariables, eventChannel chan<- *ActionEvent) {
+// setDependencyDepth sets the dependency depth of action and, if it is a parallel group, of its members.
+func (action *PipelineAction) setDependencyDepth(depth int) {
+	action.dependencyDepth = depth
+	for _, member := range action.Members {
+		member.setDependencyDepth(depth)
+	}
+}
+
+// environmentWithin returns executionEnvironment with the Env of action added, for an executable run by action.
+func (action *PipelineAction) environmentWithin(executionEnvironment *PipelineExecutionEnvironment) []string {
+	if len(action.Env) == 0 {
+		return executionEnvironment.ToFlattenedStrings()
+	}
+
+	environment := make([]string, 0, len(executionEnvironment.EnvironmentalVariables)+len(action.Env))
+	for k, v := range executionEnvironment.EnvironmentalVariables {
+		if _, isOverridden := action.Env[k]; !isOverridden {
+			environment = append(environment, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+
+	for k, v := range action.Env {
+		environment = append(environment, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	return environment
+}
+
+// executableOutputWaitDelay bounds how long to wait for stdout and stderr to close after an executable is killed,
+// in case the executable started children that still hold them open.
+const executableOutputWaitDelay = 5 * time.Second
+
+// runCommandWithVariablesOnStdin runs the action target with pipelineVariables as json on stdin, returning what was
+// delivered on stdin, stdout and stderr.
+func (action *PipelineAction) runCommandWithVariablesOnStdin(ctx context.Context, pipelineVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment) (stdin *bytes.Buffer, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
+	jsonBytes, err := json.Marshal(pipelineVariables)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshall variables to json: %s", err)
+	}
+
+	cmdStdin := bytes.NewBuffer(jsonBytes)
+	cmdStdout := new(bytes.Buffer)
+	cmdStderr := new(bytes.Buffer)
+
+	cmd := exec.CommandContext(ctx, action.ActionFullyQualifiedPath, action.Args...)
+	cmd.Stdout = cmdStdout
+	cmd.Stderr = cmdStderr
+	cmd.WaitDelay = executableOutputWaitDelay
+
+	cmd.Env = action.environmentWithin(executionEnvironment)
+
+	stdinWritePipe, err := cmd.StdinPipe()
+	if err != nil {
+		return cmdStdin, nil, nil, fmt.Errorf("could not connect stdin pipe: %s", err)
+	}
+
+	go func() {
+		defer stdinWritePipe.Close()
+		stdinWritePipe.Write(jsonBytes)
+	}()
+
+	if err := cmd.Run(); err != nil {
+		return cmdStdin, cmdStdout, cmdStderr, explainedIfActionTimedOut(ctx, err)
+	}
+
+	return cmdStdin, cmdStdout, cmdStderr, nil
 }
 
 func (outcome *PipelineActionOutcome) WriteOutputToFile(filePath string, fileModeIfFileIsCreated os.FileMode) error {
@@ -0,0 +1,211 @@
+package jobber_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blorticus-go/jobber"
+)
+
+func collectActionEvents(action *jobber.PipelineAction, variables *jobber.PipelineVariables) []*jobber.ActionEvent {
+	actionEventChannel := make(chan *jobber.ActionEvent)
+	go action.Run(context.Background(), variables, &jobber.PipelineExecutionEnvironment{EnvironmentalVariables: map[string]string{"PATH": "/usr/bin:/bin"}}, nil, actionEventChannel)
+
+	events := make([]*jobber.ActionEvent, 0, 3)
+	for {
+		event := <-actionEventChannel
+		events = append(events, event)
+		if event.Type == jobber.ActionCompletedSuccessfully || event.Type == jobber.AnErrorOccurred {
+			return events
+		}
+	}
+}
+
+func TestValuesTransformAction(t *testing.T) {
+	action, err := jobber.PipelineActionFromStringDescriptor("values-transforms/increase-tps.sh", "testing_assets")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	variables := jobber.NewEmptyPipelineVariables(nil).
+		RescopedToUnitNamed("unit01").
+		RescopedToCaseNamed("case01").
+		WithCaseValues(map[string]any{"TPS": 100})
+
+	events := collectActionEvents(action, variables)
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	if events[0].Type != jobber.ValuesTransformCompleted {
+		t.Fatalf("expected first event to be ValuesTransformCompleted, got error = %v", events[0].Error)
+	}
+
+	if events[0].StdinBuffer == nil || events[0].StdinBuffer.Len() == 0 {
+		t.Errorf("expected StdinBuffer to contain the json delivered to the transform, but it is empty")
+	}
+
+	if events[1].Type != jobber.ActionCompletedSuccessfully {
+		t.Errorf("expected second event to be ActionCompletedSuccessfully")
+	}
+
+	if tps := variables.Values.Case["TPS"]; tps != float64(200) {
+		t.Errorf("expected .Values.Case.TPS to be transformed to (200), got (%v)", tps)
+	}
+
+	if inject := variables.Values.Unit["Sidecar"].(map[string]any)["Inject"]; inject != true {
+		t.Errorf("expected .Values.Unit.Sidecar.Inject to be transformed to (true), got (%v)", inject)
+	}
+}
+
+func TestExecutableActionIsKilledOnCancellation(t *testing.T) {
+	action, err := jobber.PipelineActionFromStringDescriptor("executables/sleeps.sh", "testing_assets")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	actionEventChannel := make(chan *jobber.ActionEvent)
+	go action.Run(ctx, jobber.NewEmptyPipelineVariables(nil), &jobber.PipelineExecutionEnvironment{EnvironmentalVariables: map[string]string{"PATH": "/usr/bin:/bin"}}, nil, actionEventChannel)
+
+	select {
+	case event := <-actionEventChannel:
+		if event.Type != jobber.AnErrorOccurred {
+			t.Errorf("expected AnErrorOccurred event after cancellation")
+		}
+		if errors.Is(event.Error, jobber.ErrorTimeExceeded) {
+			t.Errorf("did not expect cancellation to be reported as ErrorTimeExceeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("executable was not stopped when the context was cancelled")
+	}
+}
+
+func TestExecutableActionIsKilledWhenActionTimeoutPasses(t *testing.T) {
+	action, err := jobber.PipelineActionFromStringDescriptor("executables/sleeps.sh", "testing_assets")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	action.Timeouts = &jobber.ActionTimeouts{Action: 100 * time.Millisecond}
+
+	actionEventChannel := make(chan *jobber.ActionEvent)
+	go action.Run(context.Background(), jobber.NewEmptyPipelineVariables(nil), &jobber.PipelineExecutionEnvironment{EnvironmentalVariables: map[string]string{"PATH": "/usr/bin:/bin"}}, nil, actionEventChannel)
+
+	select {
+	case event := <-actionEventChannel:
+		if event.Type != jobber.AnErrorOccurred {
+			t.Fatalf("expected AnErrorOccurred event after timeout")
+		}
+		if !errors.Is(event.Error, jobber.ErrorTimeExceeded) {
+			t.Errorf("expected error to wrap ErrorTimeExceeded, got (%s)", event.Error)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("executable was not stopped when the action timeout passed")
+	}
+}
+
+func TestExecutableActionReceivesArgsAndEnv(t *testing.T) {
+	action, err := jobber.PipelineActionFromStringDescriptor("executables/reporting/echoes-args-and-env.sh", "testing_assets")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	action.Args = []string{"--summary", "100TPS"}
+	action.Env = map[string]string{"REPORT_FORMAT": "csv"}
+
+	events := collectActionEvents(action, jobber.NewEmptyPipelineVariables(nil))
+
+	if len(events) != 2 || events[0].Type != jobber.ExecutionSuccessful {
+		t.Fatalf("expected ExecutionSuccessful then ActionCompletedSuccessfully")
+	}
+
+	if stdout := events[0].StdoutBuffer.String(); stdout != "--summary 100TPS\ncsv\n" {
+		t.Errorf("expected StdoutBuffer to contain (--summary 100TPS\\ncsv\\n), got (%s)", stdout)
+	}
+}
+
+func TestValuesTransformActionWithInvalidOutput(t *testing.T) {
+	action, err := jobber.PipelineActionFromStringDescriptor("values-transforms/emits-invalid-json.sh", "testing_assets")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	variables := jobber.NewEmptyPipelineVariables(nil).WithGlobalValues(map[string]any{"TPS": 100})
+
+	events := collectActionEvents(action, variables)
+
+	if len(events) != 1 || events[0].Type != jobber.AnErrorOccurred {
+		t.Fatalf("expected exactly one AnErrorOccurred event")
+	}
+
+	if stderr := events[0].StderrBuffer.String(); stderr != "transform failed\n" {
+		t.Errorf("expected StderrBuffer to contain (transform failed\\n), got (%s)", stderr)
+	}
+
+	if tps := variables.Values.Global["TPS"]; tps != 100 {
+		t.Errorf("expected .Values.Global.TPS to be unchanged at (100), got (%v)", tps)
+	}
+}
+
+func TestActionsAreSkippedInDryRunUnlessValuesTransformsAreRun(t *testing.T) {
+	for _, testCase := range []struct {
+		testName                 string
+		descriptor               string
+		valuesTransformsInDryRun bool
+		expectedEventTypes       []jobber.ActionEventType
+	}{
+		{
+			testName:           "executable",
+			descriptor:         "executables/sleeps.sh",
+			expectedEventTypes: []jobber.ActionEventType{jobber.ActionSkippedInDryRun, jobber.ActionCompletedSuccessfully},
+		},
+		{
+			testName:                 "executable when values-transforms are run",
+			descriptor:               "executables/sleeps.sh",
+			valuesTransformsInDryRun: true,
+			expectedEventTypes:       []jobber.ActionEventType{jobber.ActionSkippedInDryRun, jobber.ActionCompletedSuccessfully},
+		},
+		{
+			testName:           "values-transform",
+			descriptor:         "values-transforms/increase-tps.sh",
+			expectedEventTypes: []jobber.ActionEventType{jobber.ActionSkippedInDryRun, jobber.ActionCompletedSuccessfully},
+		},
+		{
+			testName:                 "values-transform when values-transforms are run",
+			descriptor:               "values-transforms/increase-tps.sh",
+			valuesTransformsInDryRun: true,
+			expectedEventTypes:       []jobber.ActionEventType{jobber.ValuesTransformCompleted, jobber.ActionCompletedSuccessfully},
+		},
+	} {
+		action, err := jobber.PipelineActionFromStringDescriptor(testCase.descriptor, "testing_assets")
+		if err != nil {
+			t.Fatalf("did not expect an error, but got error = %s", err)
+		}
+
+		executionEnvironment := &jobber.PipelineExecutionEnvironment{
+			EnvironmentalVariables:   map[string]string{"PATH": "/usr/bin:/bin"},
+			DryRun:                   true,
+			ValuesTransformsInDryRun: testCase.valuesTransformsInDryRun,
+		}
+
+		actionEventChannel := make(chan *jobber.ActionEvent)
+		go action.Run(context.Background(), jobber.NewEmptyPipelineVariables(nil), executionEnvironment, nil, actionEventChannel)
+
+		for _, expectedEventType := range testCase.expectedEventTypes {
+			select {
+			case event := <-actionEventChannel:
+				if event.Type != expectedEventType {
+					t.Errorf("[%s] expected event type (%d), got (%d)", testCase.testName, expectedEventType, event.Type)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("[%s] action did not complete in dry run", testCase.testName)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package jobber
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ResourceMode determines how a resources action submits each resource.
+type ResourceMode string
+
+const (
+	// ResourceModeCreate creates each resource, failing if it already exists.  It is the default.
+	ResourceModeCreate ResourceMode = "Create"
+
+	// ResourceModeApply submits each resource with server-side apply, so that it is created if it does not exist,
+	// and is otherwise updated to match.
+	ResourceModeApply ResourceMode = "Apply"
+)
+
+// ResourceModeAnnotation, on a resource in a resources template, overrides the Mode of the Pipeline Action for that
+// resource.  The value is Create or Apply.
+const ResourceModeAnnotation = "jobber.blorticus-go.github.io/mode"
+
+// ResourceModeFromString returns the ResourceMode named by s, ignoring case.
+func ResourceModeFromString(s string) (ResourceMode, error) {
+	for _, mode := range []ResourceMode{ResourceModeCreate, ResourceModeApply} {
+		if strings.EqualFold(s, string(mode)) {
+			return mode, nil
+		}
+	}
+
+	return "", fmt.Errorf("mode (%s) must be Create or Apply", s)
+}
+
+// ResourceModeFor returns the mode in which object is submitted by an action whose Mode is actionMode.  The
+// ResourceModeAnnotation of object, if it has one, takes precedence.
+func ResourceModeFor(object *unstructured.Unstructured, actionMode ResourceMode) (ResourceMode, error) {
+	if annotationValue, isAnnotated := object.GetAnnotations()[ResourceModeAnnotation]; isAnnotated {
+		mode, err := ResourceModeFromString(annotationValue)
+		if err != nil {
+			return "", fmt.Errorf("annotation (%s) is invalid: %s", ResourceModeAnnotation, err)
+		}
+		return mode, nil
+	}
+
+	if actionMode == "" {
+		return ResourceModeCreate, nil
+	}
+
+	return actionMode, nil
+}
+
+// RevertConfigurationFor returns the apply configuration that, applied by the jobber field manager, reverts the
+// application of applied to original.  For each field that applied sets, it holds the value of that field in
+// original.  A field that original does not have is left out, so that jobber releases it, and it is removed unless
+// another field manager has since claimed it.  Fields that applied does not set are left out too, so changes that
+// other field managers make are not undone.
+func RevertConfigurationFor(applied *unstructured.Unstructured, original *unstructured.Unstructured) *unstructured.Unstructured {
+	revert := &unstructured.Unstructured{Object: originalValuesOfAppliedFields(applied.Object, original.Object)}
+
+	revert.SetAPIVersion(applied.GetAPIVersion())
+	revert.SetKind(applied.GetKind())
+	revert.SetName(applied.GetName())
+	if namespaceName := applied.GetNamespace(); namespaceName != "" {
+		revert.SetNamespace(namespaceName)
+	}
+
+	return revert
+}
+
+// originalValuesOfAppliedFields returns, for each key of applied that original also has, the value in original.  A
+// mapping is descended into, so that only the fields that applied sets are included; any other value (including a
+// list) is taken whole.
+func originalValuesOfAppliedFields(applied map[string]any, original map[string]any) map[string]any {
+	values := make(map[string]any)
+
+	for key, appliedValue := range applied {
+		originalValue, originalHasKey := original[key]
+		if !originalHasKey {
+			continue
+		}
+
+		appliedMapping, appliedValueIsAMapping := appliedValue.(map[string]any)
+		originalMapping, originalValueIsAMapping := originalValue.(map[string]any)
+		if appliedValueIsAMapping && originalValueIsAMapping {
+			if nestedValues := originalValuesOfAppliedFields(appliedMapping, originalMapping); len(nestedValues) > 0 {
+				values[key] = nestedValues
+			}
+			continue
+		}
+
+		values[key] = runtime.DeepCopyJSONValue(originalValue)
+	}
+
+	return values
+}
+
+// AppliedResourcePolicy determines what happens, at cleanup, to an object that already existed when a resources
+// action applied it (see ResourceModeApply).  An applied object that did not already exist was created, so it is
+// deleted like any other created resource.
+type AppliedResourcePolicy string
+
+const (
+	// AppliedResourcesLeave leaves the object as it was applied.  It is the default.
+	AppliedResourcesLeave AppliedResourcePolicy = "Leave"
+
+	// AppliedResourcesRevert restores the fields that jobber applied to the values they had before, along with the
+	// deletion of the created resources (see RevertConfigurationFor).
+	AppliedResourcesRevert AppliedResourcePolicy = "Revert"
+)
+
+// AppliedResourcePolicyFromString returns the AppliedResourcePolicy named by s, ignoring case.
+func AppliedResourcePolicyFromString(s string) (AppliedResourcePolicy, error) {
+	for _, policy := range []AppliedResourcePolicy{AppliedResourcesLeave, AppliedResourcesRevert} {
+		if strings.EqualFold(s, string(policy)) {
+			return policy, nil
+		}
+	}
+
+	return "", fmt.Errorf("applied resource policy (%s) must be Leave or Revert", s)
+}
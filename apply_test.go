@@ -0,0 +1,157 @@
+package jobber_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestResourceModeFor(t *testing.T) {
+	objectWithAnnotations := func(annotations map[string]any) *unstructured.Unstructured {
+		metadata := map[string]any{"name": "mesh-wide"}
+		if annotations != nil {
+			metadata["annotations"] = annotations
+		}
+
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "security.istio.io/v1beta1",
+			"kind":       "PeerAuthentication",
+			"metadata":   metadata,
+		}}
+	}
+
+	for _, testCase := range []struct {
+		testName      string
+		object        *unstructured.Unstructured
+		actionMode    jobber.ResourceMode
+		expectedMode  jobber.ResourceMode
+		expectAnError bool
+	}{
+		{
+			testName:     "no action Mode and no annotation",
+			object:       objectWithAnnotations(nil),
+			expectedMode: jobber.ResourceModeCreate,
+		},
+		{
+			testName:     "action Mode",
+			object:       objectWithAnnotations(map[string]any{"team": "perf"}),
+			actionMode:   jobber.ResourceModeApply,
+			expectedMode: jobber.ResourceModeApply,
+		},
+		{
+			testName:     "annotation",
+			object:       objectWithAnnotations(map[string]any{jobber.ResourceModeAnnotation: "apply"}),
+			expectedMode: jobber.ResourceModeApply,
+		},
+		{
+			testName:     "annotation overrides action Mode",
+			object:       objectWithAnnotations(map[string]any{jobber.ResourceModeAnnotation: "Create"}),
+			actionMode:   jobber.ResourceModeApply,
+			expectedMode: jobber.ResourceModeCreate,
+		},
+		{
+			testName:      "invalid annotation",
+			object:        objectWithAnnotations(map[string]any{jobber.ResourceModeAnnotation: "Replace"}),
+			expectAnError: true,
+		},
+	} {
+		mode, err := jobber.ResourceModeFor(testCase.object, testCase.actionMode)
+
+		if testCase.expectAnError {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got no error", testCase.testName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		if mode != testCase.expectedMode {
+			t.Errorf("[%s] expected mode = (%s), got (%s)", testCase.testName, testCase.expectedMode, mode)
+		}
+	}
+}
+
+func TestRevertConfigurationFor(t *testing.T) {
+	applied := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "security.istio.io/v1beta1",
+		"kind":       "PeerAuthentication",
+		"metadata": map[string]any{
+			"name":      "default",
+			"namespace": "istio-system",
+			"labels":    map[string]any{"team": "perf"},
+		},
+		"spec": map[string]any{
+			"mtls":                  map[string]any{"mode": "STRICT"},
+			"portLevelMtls":         map[string]any{"8080": map[string]any{"mode": "DISABLE"}},
+			"selectorMatchingPorts": []any{int64(8080)},
+		},
+	}}
+
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "security.istio.io/v1beta1",
+		"kind":       "PeerAuthentication",
+		"metadata": map[string]any{
+			"name":            "default",
+			"namespace":       "istio-system",
+			"resourceVersion": "4711",
+			"labels":          map[string]any{"owner": "mesh"},
+		},
+		"spec": map[string]any{
+			"mtls":                  map[string]any{"mode": "PERMISSIVE"},
+			"selector":              map[string]any{"matchLabels": map[string]any{"app": "gateway"}},
+			"selectorMatchingPorts": []any{int64(443), int64(8443)},
+		},
+	}}
+
+	expected := map[string]any{
+		"apiVersion": "security.istio.io/v1beta1",
+		"kind":       "PeerAuthentication",
+		"metadata": map[string]any{
+			"name":      "default",
+			"namespace": "istio-system",
+		},
+		"spec": map[string]any{
+			"mtls":                  map[string]any{"mode": "PERMISSIVE"},
+			"selectorMatchingPorts": []any{int64(443), int64(8443)},
+		},
+	}
+
+	if diff := deep.Equal(jobber.RevertConfigurationFor(applied, original).Object, expected); diff != nil {
+		t.Errorf("revert configuration differs from that expected: %v", diff)
+	}
+}
+
+func TestJobberAnnotationsAreNotSubmitted(t *testing.T) {
+	fake, client := newFakeApiServer(t)
+
+	action, err := jobber.PipelineActionFromStringDescriptor("resources/configmap01", "testing_assets")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	actionEventChannel := make(chan *jobber.ActionEvent)
+	go action.Run(context.Background(), jobber.NewEmptyPipelineVariables(client), &jobber.PipelineExecutionEnvironment{}, client, actionEventChannel)
+
+	for event := <-actionEventChannel; event.Type != jobber.ActionCompletedSuccessfully; event = <-actionEventChannel {
+		if event.Type == jobber.AnErrorOccurred {
+			t.Fatalf("expected no error, got error = (%s)", event.Error)
+		}
+	}
+
+	var appliedObject unstructured.Unstructured
+	if err := json.Unmarshal(fake.objects["/api/v1/namespaces/perftest-x7k2/configmaps/settings"], &appliedObject.Object); err != nil {
+		t.Fatalf("expected the ConfigMap to be applied, got error = (%s)", err)
+	}
+
+	if diff := deep.Equal(appliedObject.GetAnnotations(), map[string]string{"team": "perf"}); diff != nil {
+		t.Errorf("submitted annotations differ from those expected: %v", diff)
+	}
+}
@@ -2,10 +2,12 @@ package jobber
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 )
 
 type TestCaseAssetsDirectoryCreationOutcome struct {
@@ -20,10 +22,29 @@ type TestCaseDirectoryPaths struct {
 	ValuesTransforms  string
 	Executables       string
 	RetrievedAssets   string
+
+	// Iterations is set only if the Test Case has more than one iteration, in which case it holds the paths for
+	// each iteration, in order, and the other subdirectory paths are empty.  The Root of an iteration is its
+	// iteration-<n> directory.
+	Iterations []*TestCaseDirectoryPaths
+}
+
+// ForIteration returns the paths for an iteration (counting from 1) of the Test Case.  If the Test Case has only
+// one iteration, that is paths itself.
+func (paths *TestCaseDirectoryPaths) ForIteration(iteration uint) *TestCaseDirectoryPaths {
+	if len(paths.Iterations) == 0 {
+		return paths
+	}
+
+	return paths.Iterations[iteration-1]
 }
 
+// ContextualAssetsDirectoryManager creates and tracks the assets directories for a Test.  Its methods may be called
+// from concurrently running Test Cases.
 type ContextualAssetsDirectoryManager struct {
+	mutex                                        sync.RWMutex
 	testRootAssetDirectoryPath                   string
+	reusingExistingTestRootAssetDirectory        bool
 	testUnitAssetDirectoryPathByUnitName         map[string]string
 	testCaseAssetsDirectoryPathByUnitAndCaseName map[string]map[string]*TestCaseDirectoryPaths
 }
@@ -50,18 +71,45 @@ func (m *ContextualAssetsDirectoryManager) CreateTestAssetsRootDirectory() *Test
 	}
 }
 
+// AdoptExistingTestAssetsRootDirectory uses the assets root directory from a previous Test rather than creating a
+// new one.  This is used when resuming a Test.  Test Unit directories that already exist are then used as-is, while
+// Test Case directories that already exist are removed and created anew.
+func (m *ContextualAssetsDirectoryManager) AdoptExistingTestAssetsRootDirectory(directoryPath string) error {
+	fileInfo, err := os.Stat(directoryPath)
+	if err != nil {
+		return err
+	}
+
+	if !fileInfo.IsDir() {
+		return fmt.Errorf("(%s) is not a directory", directoryPath)
+	}
+
+	m.testRootAssetDirectoryPath = directoryPath
+	m.reusingExistingTestRootAssetDirectory = true
+
+	return nil
+}
+
 func (m *ContextualAssetsDirectoryManager) CreateTestUnitDirectory(testUnit *TestUnit) *TestCaseAssetsDirectoryCreationOutcome {
 	if m.testRootAssetDirectoryPath == "" {
 		panic("attempt to CreateTestUnitDirectory() before CreateTestAssetsRootDirectory()")
 	}
 
 	proposedPath := fmt.Sprintf("%s/%s", m.testRootAssetDirectoryPath, testUnit.Name)
-	m.testUnitAssetDirectoryPathByUnitName[testUnit.Name] = proposedPath
 
+	m.mutex.Lock()
+	m.testUnitAssetDirectoryPathByUnitName[testUnit.Name] = proposedPath
 	m.testCaseAssetsDirectoryPathByUnitAndCaseName[testUnit.Name] = make(map[string]*TestCaseDirectoryPaths)
+	m.mutex.Unlock()
 
 	err := os.Mkdir(proposedPath, 0700)
 
+	if err != nil && errors.Is(err, os.ErrExist) && m.reusingExistingTestRootAssetDirectory {
+		return &TestCaseAssetsDirectoryCreationOutcome{
+			SuccessfullyCreatedDirectoryPaths: []string{},
+		}
+	}
+
 	if err != nil {
 		return &TestCaseAssetsDirectoryCreationOutcome{
 			DirectoryPathOfFailedCreation: proposedPath,
@@ -74,7 +122,10 @@ func (m *ContextualAssetsDirectoryManager) CreateTestUnitDirectory(testUnit *Tes
 	}
 }
 
-func (m *ContextualAssetsDirectoryManager) CreateTestCaseDirectories(testUnit *TestUnit, testCase *TestCase) *TestCaseAssetsDirectoryCreationOutcome {
+// CreateTestCaseDirectories creates the assets directory for a Test Case and, under it, the subdirectories for the
+// assets of each Pipeline Action.  If numberOfIterations is greater than one, the subdirectories are created under
+// a directory named iteration-<n> for each iteration instead.
+func (m *ContextualAssetsDirectoryManager) CreateTestCaseDirectories(testUnit *TestUnit, testCase *TestCase, numberOfIterations uint) *TestCaseAssetsDirectoryCreationOutcome {
 	testUnitAssetDirectoryPath := m.TestUnitAssetDirectoryPathFor(testUnit)
 	if testUnitAssetDirectoryPath == "" {
 		panic("attempt to CreateTestCaseDirectories() before corresponding CreateTestUnitDirectory()")
@@ -86,6 +137,14 @@ func (m *ContextualAssetsDirectoryManager) CreateTestCaseDirectories(testUnit *T
 
 	proposedTestCaseRootPath := fmt.Sprintf("%s/%s", testUnitAssetDirectoryPath, testCase.Name)
 
+	if m.reusingExistingTestRootAssetDirectory {
+		if err := os.RemoveAll(proposedTestCaseRootPath); err != nil {
+			outcome.DirectoryPathOfFailedCreation = proposedTestCaseRootPath
+			outcome.DirectoryCreationFailureError = fmt.Errorf("failed to remove directory from previous attempt: %s", err)
+			return outcome
+		}
+	}
+
 	if err := os.Mkdir(proposedTestCaseRootPath, 0700); err != nil {
 		outcome.DirectoryPathOfFailedCreation = proposedTestCaseRootPath
 		outcome.DirectoryCreationFailureError = err
@@ -94,30 +153,123 @@ func (m *ContextualAssetsDirectoryManager) CreateTestCaseDirectories(testUnit *T
 
 	outcome.SuccessfullyCreatedDirectoryPaths = append(outcome.SuccessfullyCreatedDirectoryPaths, proposedTestCaseRootPath)
 
-	proposedExpandedTemplatesPath := fmt.Sprintf("%s/%s", proposedTestCaseRootPath, "expanded-templates")
-	proposedRetrievedAssetsPath := fmt.Sprintf("%s/%s", proposedTestCaseRootPath, "retrieved-assets")
-	proposedValuesTransformOutputPath := fmt.Sprintf("%s/%s", proposedTestCaseRootPath, "values-transform-output")
-	proposedExecutableOutputPath := fmt.Sprintf("%s/%s", proposedTestCaseRootPath, "executable-output")
+	testCasePaths := &TestCaseDirectoryPaths{Root: proposedTestCaseRootPath}
+
+	if numberOfIterations <= 1 {
+		if err := createTestCaseSubdirectoriesIn(testCasePaths, outcome); err != nil {
+			return outcome
+		}
+	} else {
+		for iteration := uint(1); iteration <= numberOfIterations; iteration++ {
+			iterationPaths := &TestCaseDirectoryPaths{Root: fmt.Sprintf("%s/iteration-%d", proposedTestCaseRootPath, iteration)}
+
+			if err := os.Mkdir(iterationPaths.Root, 0700); err != nil {
+				outcome.DirectoryPathOfFailedCreation = iterationPaths.Root
+				outcome.DirectoryCreationFailureError = err
+				return outcome
+			}
+
+			outcome.SuccessfullyCreatedDirectoryPaths = append(outcome.SuccessfullyCreatedDirectoryPaths, iterationPaths.Root)
+
+			if err := createTestCaseSubdirectoriesIn(iterationPaths, outcome); err != nil {
+				return outcome
+			}
+
+			testCasePaths.Iterations = append(testCasePaths.Iterations, iterationPaths)
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.testCaseAssetsDirectoryPathByUnitAndCaseName[testUnit.Name][testCase.Name] = testCasePaths
+
+	return outcome
+}
+
+// createTestCaseSubdirectoriesIn creates the assets subdirectories under paths.Root, and sets their paths in
+// paths.  The outcome of each creation is added to outcome.  On failure, the error is returned.
+func createTestCaseSubdirectoriesIn(paths *TestCaseDirectoryPaths, outcome *TestCaseAssetsDirectoryCreationOutcome) error {
+	proposedExpandedTemplatesPath := fmt.Sprintf("%s/%s", paths.Root, "expanded-templates")
+	proposedRetrievedAssetsPath := fmt.Sprintf("%s/%s", paths.Root, "retrieved-assets")
+	proposedValuesTransformOutputPath := fmt.Sprintf("%s/%s", paths.Root, "values-transform-output")
+	proposedExecutableOutputPath := fmt.Sprintf("%s/%s", paths.Root, "executable-output")
 
 	for _, proposedPath := range []string{proposedExpandedTemplatesPath, proposedRetrievedAssetsPath, proposedValuesTransformOutputPath, proposedExecutableOutputPath} {
 		if err := os.Mkdir(proposedPath, 0700); err != nil {
 			outcome.DirectoryPathOfFailedCreation = proposedPath
 			outcome.DirectoryCreationFailureError = err
-			return outcome
+			return err
 		}
 
 		outcome.SuccessfullyCreatedDirectoryPaths = append(outcome.SuccessfullyCreatedDirectoryPaths, proposedPath)
 	}
 
-	m.testCaseAssetsDirectoryPathByUnitAndCaseName[testUnit.Name][testCase.Name] = &TestCaseDirectoryPaths{
-		Root:              proposedTestCaseRootPath,
-		ExpandedTemplates: proposedExpandedTemplatesPath,
-		RetrievedAssets:   proposedRetrievedAssetsPath,
-		ValuesTransforms:  proposedValuesTransformOutputPath,
-		Executables:       proposedExecutableOutputPath,
+	paths.ExpandedTemplates = proposedExpandedTemplatesPath
+	paths.RetrievedAssets = proposedRetrievedAssetsPath
+	paths.ValuesTransforms = proposedValuesTransformOutputPath
+	paths.Executables = proposedExecutableOutputPath
+
+	return nil
+}
+
+// Names of the assets directories for the setup and teardown Pipelines.  They are created in the assets root
+// directory for the Test, and in the Test Unit directory for a Test Unit.
+const (
+	setupAssetsDirectoryName    = "_setup"
+	teardownAssetsDirectoryName = "_teardown"
+)
+
+// isReservedAssetDirectoryName returns true if name cannot be used for a Test Unit or Test Case, because its assets
+// directory would collide with a setup or teardown assets directory.
+func isReservedAssetDirectoryName(name string) bool {
+	return name == setupAssetsDirectoryName || name == teardownAssetsDirectoryName
+}
+
+// CreateSetupAndTeardownDirectories creates the assets directories for the setup and teardown Pipelines of
+// testUnit, or of the Test if testUnit is nil, each with the subdirectories for the assets of Pipeline Actions.
+// Directories that exist from a previous attempt are removed and created anew.
+func (m *ContextualAssetsDirectoryManager) CreateSetupAndTeardownDirectories(testUnit *TestUnit) (setupPaths *TestCaseDirectoryPaths, teardownPaths *TestCaseDirectoryPaths, outcome *TestCaseAssetsDirectoryCreationOutcome) {
+	parentDirectoryPath := m.testRootAssetDirectoryPath
+	if testUnit != nil {
+		if parentDirectoryPath = m.TestUnitAssetDirectoryPathFor(testUnit); parentDirectoryPath == "" {
+			panic("attempt to CreateSetupAndTeardownDirectories() before corresponding CreateTestUnitDirectory()")
+		}
+	}
+
+	outcome = &TestCaseAssetsDirectoryCreationOutcome{
+		SuccessfullyCreatedDirectoryPaths: make([]string, 0),
 	}
 
-	return outcome
+	paths := make([]*TestCaseDirectoryPaths, 0, 2)
+
+	for _, directoryName := range []string{setupAssetsDirectoryName, teardownAssetsDirectoryName} {
+		scopePaths := &TestCaseDirectoryPaths{Root: fmt.Sprintf("%s/%s", parentDirectoryPath, directoryName)}
+
+		if m.reusingExistingTestRootAssetDirectory {
+			if err := os.RemoveAll(scopePaths.Root); err != nil {
+				outcome.DirectoryPathOfFailedCreation = scopePaths.Root
+				outcome.DirectoryCreationFailureError = fmt.Errorf("failed to remove directory from previous attempt: %s", err)
+				return nil, nil, outcome
+			}
+		}
+
+		if err := os.Mkdir(scopePaths.Root, 0700); err != nil {
+			outcome.DirectoryPathOfFailedCreation = scopePaths.Root
+			outcome.DirectoryCreationFailureError = err
+			return nil, nil, outcome
+		}
+
+		outcome.SuccessfullyCreatedDirectoryPaths = append(outcome.SuccessfullyCreatedDirectoryPaths, scopePaths.Root)
+
+		if err := createTestCaseSubdirectoriesIn(scopePaths, outcome); err != nil {
+			return nil, nil, outcome
+		}
+
+		paths = append(paths, scopePaths)
+	}
+
+	return paths[0], paths[1], outcome
 }
 
 func (m *ContextualAssetsDirectoryManager) TestRootAssetDirectoryPath() string {
@@ -125,10 +277,16 @@ func (m *ContextualAssetsDirectoryManager) TestRootAssetDirectoryPath() string {
 }
 
 func (m *ContextualAssetsDirectoryManager) TestUnitAssetDirectoryPathFor(testUnit *TestUnit) string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
 	return m.testUnitAssetDirectoryPathByUnitName[testUnit.Name]
 }
 
 func (m *ContextualAssetsDirectoryManager) TestCaseAssetsDirectoryPathsFor(testUnit *TestUnit, testCase *TestCase) *TestCaseDirectoryPaths {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
 	if s := m.testCaseAssetsDirectoryPathByUnitAndCaseName[testUnit.Name]; s != nil {
 		return s[testCase.Name]
 	}
@@ -136,6 +294,52 @@ func (m *ContextualAssetsDirectoryManager) TestCaseAssetsDirectoryPathsFor(testU
 	return nil
 }
 
+// testCaseFailureMarkerFileName is the name of the file written to the assets directory of a failed Test Case.
+const testCaseFailureMarkerFileName = "FAILED"
+
+// WriteFailureMarkerFor writes a file named FAILED, containing the error that caused the failure, to the assets
+// directory of a Test Case.  Nothing is written if the Test Case assets directory was not created.
+func (m *ContextualAssetsDirectoryManager) WriteFailureMarkerFor(testUnit *TestUnit, testCase *TestCase, testCaseError error) error {
+	testCasePaths := m.TestCaseAssetsDirectoryPathsFor(testUnit, testCase)
+	if testCasePaths == nil {
+		return nil
+	}
+
+	return os.WriteFile(fmt.Sprintf("%s/%s", testCasePaths.Root, testCaseFailureMarkerFileName), []byte(testCaseError.Error()+"\n"), 0640)
+}
+
+// skippedActionsFileName is the name of the file, in the assets directory of a Test Case, that lists the Pipeline
+// Actions that were skipped because their When condition was not satisfied.
+const skippedActionsFileName = "skipped-actions.txt"
+
+// skippedActionsFilePath returns the path to the skipped actions file under paths.Root.
+func skippedActionsFilePath(paths *TestCaseDirectoryPaths) string {
+	return fmt.Sprintf("%s/%s", paths.Root, skippedActionsFileName)
+}
+
+// noteSkippedAction appends a line for a skipped Pipeline Action to the skipped actions file under paths.Root,
+// creating the file if it does not exist.
+func noteSkippedAction(paths *TestCaseDirectoryPaths, actionDescriptor string, condition *ActionCondition, expansion string) error {
+	f, err := os.OpenFile(skippedActionsFilePath(paths), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "%s: When (%s) expanded to (%s)\n", actionDescriptor, condition.Expression, expansion)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// WriteFileInTestRootDirectory writes a file named fileName in the assets root directory, replacing it if it
+// already exists, and returns the path to the file.
+func (m *ContextualAssetsDirectoryManager) WriteFileInTestRootDirectory(fileName string, contents []byte) (string, error) {
+	filePath := fmt.Sprintf("%s/%s", m.testRootAssetDirectoryPath, fileName)
+	return filePath, os.WriteFile(filePath, contents, 0600)
+}
+
 func (m *ContextualAssetsDirectoryManager) GenerateArchiveFileAt(archiveFilePath string) error {
 	stderrBuffer := new(bytes.Buffer)
 
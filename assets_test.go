@@ -0,0 +1,101 @@
+package jobber_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+)
+
+func TestTestCaseDirectoriesForIterations(t *testing.T) {
+	m := jobber.NewContextualAssetsDirectoryManager()
+	if outcome := m.CreateTestAssetsRootDirectory(); outcome.DirectoryCreationFailureError != nil {
+		t.Fatalf("did not expect an error, but got error = %s", outcome.DirectoryCreationFailureError)
+	}
+	defer m.RemoveAssetsDirectory()
+
+	testUnit := &jobber.TestUnit{Name: "NoSidecar"}
+	if outcome := m.CreateTestUnitDirectory(testUnit); outcome.DirectoryCreationFailureError != nil {
+		t.Fatalf("did not expect an error, but got error = %s", outcome.DirectoryCreationFailureError)
+	}
+
+	for _, testCase := range []struct {
+		testCase           *jobber.TestCase
+		numberOfIterations uint
+	}{
+		{&jobber.TestCase{Name: "100TPS"}, 1},
+		{&jobber.TestCase{Name: "5000TPS"}, 3},
+	} {
+		if outcome := m.CreateTestCaseDirectories(testUnit, testCase.testCase, testCase.numberOfIterations); outcome.DirectoryCreationFailureError != nil {
+			t.Fatalf("[%s] did not expect an error, but got error = %s", testCase.testCase.Name, outcome.DirectoryCreationFailureError)
+		}
+
+		paths := m.TestCaseAssetsDirectoryPathsFor(testUnit, testCase.testCase)
+		expectedRoot := fmt.Sprintf("%s/NoSidecar/%s", m.TestRootAssetDirectoryPath(), testCase.testCase.Name)
+		if paths.Root != expectedRoot {
+			t.Errorf("[%s] expected Root (%s), got (%s)", testCase.testCase.Name, expectedRoot, paths.Root)
+		}
+
+		for iteration := uint(1); iteration <= testCase.numberOfIterations; iteration++ {
+			expectedIterationRoot := expectedRoot
+			if testCase.numberOfIterations > 1 {
+				expectedIterationRoot = fmt.Sprintf("%s/iteration-%d", expectedRoot, iteration)
+			}
+
+			iterationPaths := paths.ForIteration(iteration)
+			if iterationPaths.Root != expectedIterationRoot {
+				t.Errorf("[%s] expected Root of iteration %d to be (%s), got (%s)", testCase.testCase.Name, iteration, expectedIterationRoot, iterationPaths.Root)
+			}
+
+			if expected := expectedIterationRoot + "/retrieved-assets"; iterationPaths.RetrievedAssets != expected {
+				t.Errorf("[%s] expected RetrievedAssets of iteration %d to be (%s), got (%s)", testCase.testCase.Name, iteration, expected, iterationPaths.RetrievedAssets)
+			} else if fileInfo, err := os.Stat(expected); err != nil || !fileInfo.IsDir() {
+				t.Errorf("[%s] expected directory (%s) to exist", testCase.testCase.Name, expected)
+			}
+		}
+	}
+}
+
+func TestSetupAndTeardownDirectories(t *testing.T) {
+	m := jobber.NewContextualAssetsDirectoryManager()
+	if outcome := m.CreateTestAssetsRootDirectory(); outcome.DirectoryCreationFailureError != nil {
+		t.Fatalf("did not expect an error, but got error = %s", outcome.DirectoryCreationFailureError)
+	}
+	defer m.RemoveAssetsDirectory()
+
+	testUnit := &jobber.TestUnit{Name: "NoSidecar"}
+	if outcome := m.CreateTestUnitDirectory(testUnit); outcome.DirectoryCreationFailureError != nil {
+		t.Fatalf("did not expect an error, but got error = %s", outcome.DirectoryCreationFailureError)
+	}
+
+	for _, testCase := range []struct {
+		testUnit           *jobber.TestUnit
+		expectedParentPath string
+		scopeName          string
+	}{
+		{nil, m.TestRootAssetDirectoryPath(), "Test"},
+		{testUnit, m.TestRootAssetDirectoryPath() + "/NoSidecar", "Test Unit"},
+	} {
+		setupPaths, teardownPaths, outcome := m.CreateSetupAndTeardownDirectories(testCase.testUnit)
+		if outcome.DirectoryCreationFailureError != nil {
+			t.Fatalf("[%s] did not expect an error, but got error = %s", testCase.scopeName, outcome.DirectoryCreationFailureError)
+		}
+
+		for _, expected := range []struct {
+			paths *jobber.TestCaseDirectoryPaths
+			root  string
+		}{
+			{setupPaths, testCase.expectedParentPath + "/_setup"},
+			{teardownPaths, testCase.expectedParentPath + "/_teardown"},
+		} {
+			if expected.paths.Root != expected.root {
+				t.Errorf("[%s] expected Root (%s), got (%s)", testCase.scopeName, expected.root, expected.paths.Root)
+			}
+
+			if fileInfo, err := os.Stat(expected.paths.ExpandedTemplates); err != nil || !fileInfo.IsDir() {
+				t.Errorf("[%s] expected directory (%s) to exist", testCase.scopeName, expected.paths.ExpandedTemplates)
+			}
+		}
+	}
+}
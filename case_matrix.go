@@ -0,0 +1,314 @@
+package jobber
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigurationMatrixAxis is a parameter of a Case Matrix and the values it takes.  Name is a path, with elements
+// separated by dots, into the Values of the generated Test Cases (e.g., Sidecar.WorkerThreads).
+type ConfigurationMatrixAxis struct {
+	Name   string
+	Values []any
+}
+
+// ConfigurationMatrixAxes are the axes of a Case Matrix.  In the configuration file, they are a mapping from axis
+// name to list of values.  The order of the mapping is retained, since it determines the order of the generated
+// Test Cases.
+type ConfigurationMatrixAxes []*ConfigurationMatrixAxis
+
+func (axes *ConfigurationMatrixAxes) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: .Test.CaseMatrix.Axes must be a mapping", node.Line)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		axis := &ConfigurationMatrixAxis{Name: node.Content[i].Value}
+		if err := node.Content[i+1].Decode(&axis.Values); err != nil {
+			return fmt.Errorf("line %d: values of axis (%s) must be a list: %s", node.Content[i+1].Line, axis.Name, err)
+		}
+
+		*axes = append(*axes, axis)
+	}
+
+	return nil
+}
+
+// ConfigurationCaseMatrix generates Test Cases from every combination of the values of its Axes.  A combination
+// that matches every entry of any Exclude element is not generated.  Each Include element is an additional
+// combination.  The name of a generated Test Case is NameTemplate, expanded with the combination as the data (so
+// an axis is referenced as {{ .TPS }}, or {{ index . "Sidecar.WorkerThreads" }} if its name contains a dot).
+// Without NameTemplate, the name lists the combination (e.g., TPS=100,WorkerThreads=2).  The Values of a generated
+// Test Case are a copy of Values, with the value of each axis set at the path given by the axis name.
+type ConfigurationCaseMatrix struct {
+	Axes         ConfigurationMatrixAxes `yaml:"Axes"`
+	Include      []map[string]any        `yaml:"Include"`
+	Exclude      []map[string]any        `yaml:"Exclude"`
+	NameTemplate string                  `yaml:"NameTemplate"`
+	Tags         []string                `yaml:"Tags"`
+	Values       map[string]any          `yaml:"Values"`
+}
+
+// matrixCombination is a set of axis values for a generated Test Case.  names holds the keys of values in the
+// order in which they are listed in a default Test Case name.
+type matrixCombination struct {
+	names  []string
+	values map[string]any
+}
+
+// expandCaseMatrix appends the Test Cases generated by .Test.CaseMatrix, if there is one, to .Test.Cases.
+func (c *Configuration) expandCaseMatrix() error {
+	if c.Test == nil || c.Test.CaseMatrix == nil {
+		return nil
+	}
+
+	matrix := c.Test.CaseMatrix
+
+	if len(matrix.Axes) == 0 && len(matrix.Include) == 0 {
+		return fmt.Errorf(".Test.CaseMatrix must have at least one axis or Include entry")
+	}
+
+	var nameTemplate *template.Template
+	if matrix.NameTemplate != "" {
+		var err error
+		if nameTemplate, err = template.New("NameTemplate").Option("missingkey=error").Parse(matrix.NameTemplate); err != nil {
+			return fmt.Errorf(".Test.CaseMatrix.NameTemplate is invalid: %s", err)
+		}
+	}
+
+	existingCaseNames := make(map[string]bool)
+	for _, testCase := range c.Test.Cases {
+		existingCaseNames[testCase.Name] = true
+	}
+
+	for _, combination := range matrix.combinations() {
+		testCase, err := matrix.testCaseFor(combination, nameTemplate)
+		if err != nil {
+			return err
+		}
+
+		if existingCaseNames[testCase.Name] {
+			return fmt.Errorf(".Test.CaseMatrix generates a Test Case named (%s), but a Test Case with that name already exists", testCase.Name)
+		}
+		existingCaseNames[testCase.Name] = true
+
+		c.Test.Cases = append(c.Test.Cases, testCase)
+	}
+
+	return nil
+}
+
+// combinations returns the combinations of axis values, with the values of the first axis changing slowest,
+// less those that are excluded, followed by the included combinations that are not already present.
+func (matrix *ConfigurationCaseMatrix) combinations() []*matrixCombination {
+	axisNames := make([]string, len(matrix.Axes))
+	for i, axis := range matrix.Axes {
+		axisNames[i] = axis.Name
+	}
+
+	combinations := make([]*matrixCombination, 0)
+
+	if len(matrix.Axes) > 0 {
+		var generate func(axisIndex int, values map[string]any)
+		generate = func(axisIndex int, values map[string]any) {
+			if axisIndex == len(matrix.Axes) {
+				combination := &matrixCombination{names: axisNames, values: make(map[string]any, len(values))}
+				for k, v := range values {
+					combination.values[k] = v
+				}
+				if !matrix.excludes(combination) {
+					combinations = append(combinations, combination)
+				}
+				return
+			}
+
+			for _, value := range matrix.Axes[axisIndex].Values {
+				values[matrix.Axes[axisIndex].Name] = value
+				generate(axisIndex+1, values)
+			}
+		}
+
+		generate(0, make(map[string]any))
+	}
+
+	for _, included := range matrix.Include {
+		combination := &matrixCombination{names: namesForIncludedCombination(axisNames, included), values: included}
+
+		alreadyPresent := false
+		for _, existing := range combinations {
+			if existing.matches(included) && len(existing.values) == len(included) {
+				alreadyPresent = true
+				break
+			}
+		}
+
+		if !alreadyPresent {
+			combinations = append(combinations, combination)
+		}
+	}
+
+	return combinations
+}
+
+// namesForIncludedCombination returns the axis names that are in included, in axis order, followed by its other
+// keys in lexical order.
+func namesForIncludedCombination(axisNames []string, included map[string]any) []string {
+	names := make([]string, 0, len(included))
+	isAnAxis := make(map[string]bool)
+
+	for _, name := range axisNames {
+		isAnAxis[name] = true
+		if _, isSet := included[name]; isSet {
+			names = append(names, name)
+		}
+	}
+
+	otherNames := make([]string, 0)
+	for name := range included {
+		if !isAnAxis[name] {
+			otherNames = append(otherNames, name)
+		}
+	}
+	sort.Strings(otherNames)
+
+	return append(names, otherNames...)
+}
+
+func (matrix *ConfigurationCaseMatrix) excludes(combination *matrixCombination) bool {
+	for _, excluded := range matrix.Exclude {
+		if combination.matches(excluded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches returns true if, for every key in values, the combination has the same value.  Values are compared by
+// their string form, so that (for example) 100 in an Exclude entry matches 100 on an axis.
+func (combination *matrixCombination) matches(values map[string]any) bool {
+	for name, value := range values {
+		combinationValue, isSet := combination.values[name]
+		if !isSet || fmt.Sprint(combinationValue) != fmt.Sprint(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (combination *matrixCombination) defaultName() string {
+	pairs := make([]string, len(combination.names))
+	for i, name := range combination.names {
+		pairs[i] = fmt.Sprintf("%s=%v", name, combination.values[name])
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func (matrix *ConfigurationCaseMatrix) testCaseFor(combination *matrixCombination, nameTemplate *template.Template) (*TestCase, error) {
+	name := combination.defaultName()
+
+	if nameTemplate != nil {
+		nameBuffer := new(bytes.Buffer)
+		if err := nameTemplate.Execute(nameBuffer, combination.values); err != nil {
+			return nil, fmt.Errorf(".Test.CaseMatrix.NameTemplate failed to expand for (%s): %s", name, err)
+		}
+		name = strings.TrimSpace(nameBuffer.String())
+	}
+
+	values := copyOfValuesMap(matrix.Values)
+
+	coordinates := make(map[string]string, len(combination.values))
+
+	for _, axisName := range combination.names {
+		if err := setValueAtDottedPath(values, axisName, combination.values[axisName]); err != nil {
+			return nil, fmt.Errorf(".Test.CaseMatrix cannot set (%s) for Test Case (%s): %s", axisName, name, err)
+		}
+		coordinates[axisName] = fmt.Sprint(combination.values[axisName])
+	}
+
+	return &TestCase{
+		Name:              name,
+		Tags:              matrix.Tags,
+		Values:            values,
+		matrixCoordinates: coordinates,
+	}, nil
+}
+
+// copyOfValuesMap returns a deep copy of m, as decoded from YAML.  Nested maps and lists are copied; other values
+// are immutable.  If m is nil, an empty map is returned.
+func copyOfValuesMap(m map[string]any) map[string]any {
+	mapCopy := make(map[string]any, len(m))
+	for k, v := range m {
+		mapCopy[k] = copyOfValue(v)
+	}
+
+	return mapCopy
+}
+
+func copyOfValue(v any) any {
+	switch typed := v.(type) {
+	case map[string]any:
+		return copyOfValuesMap(typed)
+	case []any:
+		listCopy := make([]any, len(typed))
+		for i, element := range typed {
+			listCopy[i] = copyOfValue(element)
+		}
+		return listCopy
+	default:
+		return v
+	}
+}
+
+// setValueAtDottedPath sets value in m at path, which has elements separated by dots, creating any intermediate
+// maps that do not exist.
+func setValueAtDottedPath(m map[string]any, path string, value any) error {
+	pathElements := strings.Split(path, ".")
+
+	for _, element := range pathElements[:len(pathElements)-1] {
+		next, exists := m[element]
+		if !exists {
+			nextMap := make(map[string]any)
+			m[element] = nextMap
+			m = nextMap
+			continue
+		}
+
+		nextMap, isAMap := next.(map[string]any)
+		if !isAMap {
+			return fmt.Errorf("(%s) is not a map", element)
+		}
+		m = nextMap
+	}
+
+	m[pathElements[len(pathElements)-1]] = value
+	return nil
+}
+
+// matchesMatrixCoordinates returns true if testCase was generated by a Case Matrix and, for each pair in selector
+// (of the form name=value,name=value...), the Test Case has that value for the named axis.
+func (testCase *TestCase) matchesMatrixCoordinates(selector string) bool {
+	if testCase.matrixCoordinates == nil {
+		return false
+	}
+
+	for _, pair := range strings.Split(selector, ",") {
+		name, value, hasEquals := strings.Cut(pair, "=")
+		if !hasEquals {
+			return false
+		}
+
+		if coordinate, isSet := testCase.matrixCoordinates[strings.TrimSpace(name)]; !isSet || coordinate != strings.TrimSpace(value) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,131 @@
+package jobber_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+)
+
+const caseMatrixConfigTemplate = `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/jmeter-job.yaml
+  CaseMatrix:
+    Axes:
+      TPS: [100, 1000]
+      Sidecar.WorkerThreads: [2, 4]
+    Exclude:
+      - TPS: 1000
+        Sidecar.WorkerThreads: 2
+    Include:
+      - TPS: 5000
+        Sidecar.WorkerThreads: 8
+    Values:
+      ConcurrentClientConnections: 8
+      Sidecar:
+        Inject: true
+%s
+  Units:
+  - Name: NoSidecar
+`
+
+func TestCaseMatrixExpansion(t *testing.T) {
+	for _, testCase := range []struct {
+		testName           string
+		extraConfiguration string
+		expectAnError      bool
+		expectedCaseNames  []string
+	}{
+		{
+			testName:          "default names",
+			expectedCaseNames: []string{"TPS=100,Sidecar.WorkerThreads=2", "TPS=100,Sidecar.WorkerThreads=4", "TPS=1000,Sidecar.WorkerThreads=4", "TPS=5000,Sidecar.WorkerThreads=8"},
+		},
+		{
+			testName:           "name template",
+			extraConfiguration: `    NameTemplate: '{{ .TPS }}TPS-{{ index . "Sidecar.WorkerThreads" }}WT'`,
+			expectedCaseNames:  []string{"100TPS-2WT", "100TPS-4WT", "1000TPS-4WT", "5000TPS-8WT"},
+		},
+		{
+			testName:           "generated name collides with explicit case",
+			extraConfiguration: "  Cases:\n  - Name: TPS=100,Sidecar.WorkerThreads=2",
+			expectAnError:      true,
+		},
+		{
+			testName:           "name template refers to missing axis",
+			extraConfiguration: `    NameTemplate: '{{ .Connections }}'`,
+			expectAnError:      true,
+		},
+	} {
+		config, err := jobber.ReadConfigurationYamlFromReader(strings.NewReader(strings.Replace(caseMatrixConfigTemplate, "%s", testCase.extraConfiguration, 1)))
+
+		if testCase.expectAnError {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got no error", testCase.testName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		caseNames := make([]string, len(config.Test.Cases))
+		for i, c := range config.Test.Cases {
+			caseNames[i] = c.Name
+		}
+
+		if diff := deep.Equal(caseNames, testCase.expectedCaseNames); diff != nil {
+			t.Errorf("[%s] %v", testCase.testName, diff)
+		}
+	}
+}
+
+func TestCaseMatrixValuesAndOverrides(t *testing.T) {
+	config, err := jobber.ReadConfigurationYamlFromReader(strings.NewReader(strings.Replace(caseMatrixConfigTemplate, "%s", "", 1)))
+	if err != nil {
+		t.Fatalf("expected no error, got error = (%s)", err)
+	}
+
+	err = config.MergeOverrideValues(map[string]any{
+		".Test.Cases.[TPS=100].Values.ConcurrentClientConnections":                          4,
+		"Test.Cases.[TPS=5000,Sidecar.WorkerThreads=8].Values.Sidecar.Inject":               false,
+		".Test.Cases.[TPS=1000,Sidecar.WorkerThreads=4].Values.ConcurrentClientConnections": 16,
+	})
+	if err != nil {
+		t.Fatalf("expected no error on MergeOverrideValues(), got error = (%s)", err)
+	}
+
+	if diff := deep.Equal(config.Test.Cases[0].Values, map[string]any{
+		"TPS":                         100,
+		"ConcurrentClientConnections": 4,
+		"Sidecar": map[string]any{
+			"Inject":        true,
+			"WorkerThreads": 2,
+		},
+	}); diff != nil {
+		t.Errorf("values of first generated case: %v", diff)
+	}
+
+	for caseIndex, expectedConnections := range []int{4, 4, 16, 8} {
+		if connections := config.Test.Cases[caseIndex].Values["ConcurrentClientConnections"]; connections != expectedConnections {
+			t.Errorf("expected ConcurrentClientConnections of (%s) to be (%d), got (%v)", config.Test.Cases[caseIndex].Name, expectedConnections, connections)
+		}
+	}
+
+	if inject := config.Test.Cases[3].Values["Sidecar"].(map[string]any)["Inject"]; inject != false {
+		t.Errorf("expected Sidecar.Inject of included case to be overridden to (false), got (%v)", inject)
+	}
+
+	if err := config.MergeOverrideValues(map[string]any{"Test.Cases.[TPS=200].Values.ConcurrentClientConnections": 4}); err == nil {
+		t.Errorf("expected an error for a selector that matches no generated case, got no error")
+	}
+}
@@ -0,0 +1,200 @@
+package jobber
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+type TestCaseOutcome string
+
+const (
+	TestCaseSucceeded TestCaseOutcome = "Succeeded"
+	TestCaseFailed    TestCaseOutcome = "Failed"
+)
+
+// TestCheckpointEntry records the outcome of a Test Case that has run to completion or failure.
+type TestCheckpointEntry struct {
+	UnitName            string          `yaml:"UnitName"`
+	CaseName            string          `yaml:"CaseName"`
+	AssetsDirectoryPath string          `yaml:"AssetsDirectoryPath"`
+	Outcome             TestCaseOutcome `yaml:"Outcome"`
+}
+
+// TestCheckpoint records the Test Cases that have completed during a Test, so that a Test that does not complete
+// can be resumed without re-running them.  The checkpoint is written to a file next to the assets root directory
+// each time an outcome is recorded.  Its methods may be called from concurrently running Test Cases.
+// ConfigurationDigest and Selection identify the Test, so that a different Test cannot resume from the checkpoint.
+type TestCheckpoint struct {
+	AssetsRootDirectoryPath string                 `yaml:"AssetsRootDirectoryPath"`
+	ConfigurationDigest     string                 `yaml:"ConfigurationDigest"`
+	Selection               string                 `yaml:"Selection"`
+	TestCases               []*TestCheckpointEntry `yaml:"TestCases"`
+	filePath                string
+
+	// mutex is a pointer so that encoding a copy of the checkpoint does not read the state of the lock
+	mutex *sync.Mutex
+}
+
+// CheckpointFilePathFor returns the path of the checkpoint file for the Test using assetsRootDirectoryPath.
+func CheckpointFilePathFor(assetsRootDirectoryPath string) string {
+	return fmt.Sprintf("%s.checkpoint", assetsRootDirectoryPath)
+}
+
+// NewTestCheckpoint returns an empty checkpoint for the Test of config, limited to selection (which may be nil),
+// using assetsRootDirectoryPath.  The checkpoint file is not written until WriteToFile() or RecordOutcome() is
+// called.
+func NewTestCheckpoint(assetsRootDirectoryPath string, config *Configuration, selection *TestSelection) (*TestCheckpoint, error) {
+	configurationDigest, err := config.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TestCheckpoint{
+		AssetsRootDirectoryPath: assetsRootDirectoryPath,
+		ConfigurationDigest:     configurationDigest,
+		Selection:               checkpointSelectionFor(selection),
+		TestCases:               make([]*TestCheckpointEntry, 0),
+		filePath:                CheckpointFilePathFor(assetsRootDirectoryPath),
+		mutex:                   new(sync.Mutex),
+	}, nil
+}
+
+func checkpointSelectionFor(selection *TestSelection) string {
+	if selection == nil {
+		selection = NewTestSelection()
+	}
+
+	return selection.String()
+}
+
+// ReadTestCheckpointFromFile reads a checkpoint previously written by a Test.  Subsequently recorded outcomes are
+// written back to the same file.
+func ReadTestCheckpointFromFile(filePath string) (*TestCheckpoint, error) {
+	fileContents, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file (%s): %s", filePath, err)
+	}
+
+	checkpoint := &TestCheckpoint{mutex: new(sync.Mutex)}
+	if err := yaml.Unmarshal(fileContents, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint file (%s): %s", filePath, err)
+	}
+
+	if checkpoint.AssetsRootDirectoryPath == "" {
+		return nil, fmt.Errorf("checkpoint file (%s) does not define .AssetsRootDirectoryPath", filePath)
+	}
+
+	if checkpoint.TestCases == nil {
+		checkpoint.TestCases = make([]*TestCheckpointEntry, 0)
+	}
+
+	checkpoint.filePath = filePath
+
+	return checkpoint, nil
+}
+
+func (checkpoint *TestCheckpoint) FilePath() string {
+	return checkpoint.filePath
+}
+
+// VerifyThatItIsFor returns an error if checkpoint was not written by the Test of config, limited to selection
+// (which may be nil).
+func (checkpoint *TestCheckpoint) VerifyThatItIsFor(config *Configuration, selection *TestSelection) error {
+	configurationDigest, err := config.Digest()
+	if err != nil {
+		return err
+	}
+
+	if checkpoint.ConfigurationDigest != configurationDigest {
+		return fmt.Errorf("the configuration (with overrides) differs from the one with which it was written")
+	}
+
+	if selectionDescription := checkpointSelectionFor(selection); checkpoint.Selection != selectionDescription {
+		return fmt.Errorf("the selection (%s) differs from the one with which it was written (%s)", strings.TrimSpace(selectionDescription), strings.TrimSpace(checkpoint.Selection))
+	}
+
+	return nil
+}
+
+// TestCaseSucceeded returns true if the checkpoint records that the named Test Case of the named Test Unit succeeded.
+func (checkpoint *TestCheckpoint) TestCaseSucceeded(unitName string, caseName string) bool {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	if entry := checkpoint.entryFor(unitName, caseName); entry != nil {
+		return entry.Outcome == TestCaseSucceeded
+	}
+
+	return false
+}
+
+// HasRecordedOutcomes returns true if the outcome of at least one Test Case has been recorded.
+func (checkpoint *TestCheckpoint) HasRecordedOutcomes() bool {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	return len(checkpoint.TestCases) > 0
+}
+
+// RecordOutcome records the outcome of a Test Case, replacing any outcome previously recorded for it, then writes
+// the checkpoint file.
+func (checkpoint *TestCheckpoint) RecordOutcome(testUnit *TestUnit, testCase *TestCase, assetsDirectoryPath string, outcome TestCaseOutcome) error {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	if entry := checkpoint.entryFor(testUnit.Name, testCase.Name); entry != nil {
+		entry.AssetsDirectoryPath = assetsDirectoryPath
+		entry.Outcome = outcome
+	} else {
+		checkpoint.TestCases = append(checkpoint.TestCases, &TestCheckpointEntry{
+			UnitName:            testUnit.Name,
+			CaseName:            testCase.Name,
+			AssetsDirectoryPath: assetsDirectoryPath,
+			Outcome:             outcome,
+		})
+	}
+
+	return checkpoint.writeToFile()
+}
+
+// WriteToFile writes the checkpoint file.  The file is replaced atomically, so an interruption while writing cannot
+// leave a partial checkpoint.
+func (checkpoint *TestCheckpoint) WriteToFile() error {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	return checkpoint.writeToFile()
+}
+
+// RemoveFile removes the checkpoint file.
+func (checkpoint *TestCheckpoint) RemoveFile() error {
+	return os.Remove(checkpoint.filePath)
+}
+
+func (checkpoint *TestCheckpoint) writeToFile() error {
+	encoded, err := yaml.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	temporaryFilePath := fmt.Sprintf("%s.tmp", checkpoint.filePath)
+	if err := os.WriteFile(temporaryFilePath, encoded, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryFilePath, checkpoint.filePath)
+}
+
+func (checkpoint *TestCheckpoint) entryFor(unitName string, caseName string) *TestCheckpointEntry {
+	for _, entry := range checkpoint.TestCases {
+		if entry.UnitName == unitName && entry.CaseName == caseName {
+			return entry
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package jobber_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	assetsRootDirectoryPath := filepath.Join(t.TempDir(), "jobber.01")
+
+	config := GenerateTestConfiguration()
+
+	checkpoint, err := jobber.NewTestCheckpoint(assetsRootDirectoryPath, config, nil)
+	if err != nil {
+		t.Fatalf("on NewTestCheckpoint(), expected no error, got error = (%s)", err)
+	}
+
+	if checkpoint.FilePath() != assetsRootDirectoryPath+".checkpoint" {
+		t.Errorf("expected checkpoint file path (%s.checkpoint), got (%s)", assetsRootDirectoryPath, checkpoint.FilePath())
+	}
+
+	if checkpoint.HasRecordedOutcomes() {
+		t.Errorf("expected new checkpoint to have no recorded outcomes, but it does")
+	}
+
+	unit01 := &jobber.TestUnit{Name: "unit01"}
+	case01 := &jobber.TestCase{Name: "case01"}
+	case02 := &jobber.TestCase{Name: "case02"}
+
+	if err := checkpoint.RecordOutcome(unit01, case01, "/tmp/jobber.01/unit01/case01", jobber.TestCaseSucceeded); err != nil {
+		t.Fatalf("on RecordOutcome() for case01, expected no error, got error = (%s)", err)
+	}
+
+	if err := checkpoint.RecordOutcome(unit01, case02, "/tmp/jobber.01/unit01/case02", jobber.TestCaseFailed); err != nil {
+		t.Fatalf("on RecordOutcome() for case02, expected no error, got error = (%s)", err)
+	}
+
+	readCheckpoint, err := jobber.ReadTestCheckpointFromFile(checkpoint.FilePath())
+	if err != nil {
+		t.Fatalf("on ReadTestCheckpointFromFile(), expected no error, got error = (%s)", err)
+	}
+
+	configurationDigest, err := config.Digest()
+	if err != nil {
+		t.Fatalf("on Digest(), expected no error, got error = (%s)", err)
+	}
+
+	if diff := deep.Equal(readCheckpoint, &jobber.TestCheckpoint{
+		AssetsRootDirectoryPath: assetsRootDirectoryPath,
+		ConfigurationDigest:     configurationDigest,
+		Selection:               "all units and cases\n",
+		TestCases: []*jobber.TestCheckpointEntry{
+			{UnitName: "unit01", CaseName: "case01", AssetsDirectoryPath: "/tmp/jobber.01/unit01/case01", Outcome: jobber.TestCaseSucceeded},
+			{UnitName: "unit01", CaseName: "case02", AssetsDirectoryPath: "/tmp/jobber.01/unit01/case02", Outcome: jobber.TestCaseFailed},
+		},
+	}); diff != nil {
+		t.Error(strings.Join(diff, "\t"))
+	}
+
+	if !readCheckpoint.TestCaseSucceeded("unit01", "case01") {
+		t.Errorf("expected case01 to have succeeded, but it did not")
+	}
+
+	if readCheckpoint.TestCaseSucceeded("unit01", "case02") {
+		t.Errorf("expected case02 to not have succeeded, but it did")
+	}
+
+	if readCheckpoint.TestCaseSucceeded("unit02", "case01") {
+		t.Errorf("expected unrecorded case to not have succeeded, but it did")
+	}
+
+	if err := readCheckpoint.RecordOutcome(unit01, case02, "/tmp/jobber.01/unit01/case02", jobber.TestCaseSucceeded); err != nil {
+		t.Fatalf("on second RecordOutcome() for case02, expected no error, got error = (%s)", err)
+	}
+
+	if len(readCheckpoint.TestCases) != 2 || !readCheckpoint.TestCaseSucceeded("unit01", "case02") {
+		t.Errorf("expected second outcome for case02 to replace the first, but it did not")
+	}
+
+	if err := readCheckpoint.RemoveFile(); err != nil {
+		t.Fatalf("on RemoveFile(), expected no error, got error = (%s)", err)
+	}
+
+	if _, err := os.Stat(checkpoint.FilePath()); !os.IsNotExist(err) {
+		t.Errorf("expected checkpoint file to be removed, but it was not")
+	}
+
+	if _, err := jobber.ReadTestCheckpointFromFile(checkpoint.FilePath()); err == nil {
+		t.Errorf("expected error reading removed checkpoint file, got no error")
+	}
+}
+
+func TestCheckpointIsOnlyForTheTestThatWroteIt(t *testing.T) {
+	selectionOfUnit := func(pattern string) *jobber.TestSelection {
+		selection := jobber.NewTestSelection()
+		if err := selection.AddUnitPattern(pattern); err != nil {
+			t.Fatalf("on AddUnitPattern(), expected no error, got error = (%s)", err)
+		}
+		return selection
+	}
+
+	withOverride := func(overrideKey string, overrideValue any) *jobber.Configuration {
+		config := GenerateTestConfiguration()
+		if err := config.MergeOverrideValues(map[string]any{overrideKey: overrideValue}); err != nil {
+			t.Fatalf("on MergeOverrideValues(), expected no error, got error = (%s)", err)
+		}
+		return config
+	}
+
+	for _, testCase := range []struct {
+		testName          string
+		writtenSelection  *jobber.TestSelection
+		resumingConfig    *jobber.Configuration
+		resumingSelection *jobber.TestSelection
+		expectAnError     bool
+	}{
+		{
+			testName:       "same configuration",
+			resumingConfig: GenerateTestConfiguration(),
+		},
+		{
+			testName:          "same configuration and selection",
+			writtenSelection:  selectionOfUnit("NoSidecar"),
+			resumingConfig:    GenerateTestConfiguration(),
+			resumingSelection: selectionOfUnit("NoSidecar"),
+		},
+		{
+			testName:          "selection of everything",
+			resumingConfig:    GenerateTestConfiguration(),
+			resumingSelection: jobber.NewTestSelection(),
+		},
+		{
+			testName:       "overridden value",
+			resumingConfig: withOverride(".Test.GlobalValues.TestCaseDurationInSeconds", 300),
+			expectAnError:  true,
+		},
+		{
+			testName:          "different selection",
+			writtenSelection:  selectionOfUnit("NoSidecar"),
+			resumingConfig:    GenerateTestConfiguration(),
+			resumingSelection: selectionOfUnit("Sidecar"),
+			expectAnError:     true,
+		},
+		{
+			testName:         "selection removed",
+			writtenSelection: selectionOfUnit("NoSidecar"),
+			resumingConfig:   GenerateTestConfiguration(),
+			expectAnError:    true,
+		},
+	} {
+		checkpoint, err := jobber.NewTestCheckpoint(filepath.Join(t.TempDir(), "jobber.01"), GenerateTestConfiguration(), testCase.writtenSelection)
+		if err != nil {
+			t.Fatalf("[%s] on NewTestCheckpoint(), expected no error, got error = (%s)", testCase.testName, err)
+		}
+
+		if err := checkpoint.WriteToFile(); err != nil {
+			t.Fatalf("[%s] on WriteToFile(), expected no error, got error = (%s)", testCase.testName, err)
+		}
+
+		readCheckpoint, err := jobber.ReadTestCheckpointFromFile(checkpoint.FilePath())
+		if err != nil {
+			t.Fatalf("[%s] on ReadTestCheckpointFromFile(), expected no error, got error = (%s)", testCase.testName, err)
+		}
+
+		err = readCheckpoint.VerifyThatItIsFor(testCase.resumingConfig, testCase.resumingSelection)
+		if testCase.expectAnError && err == nil {
+			t.Errorf("[%s] expected an error, got no error", testCase.testName)
+		} else if !testCase.expectAnError && err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package jobber
 import (
 	"context"
 	"fmt"
+	"io"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,9 +11,11 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/remotecommand"
 )
 
 var dpk = metav1.DeletePropagationForeground
@@ -71,18 +74,18 @@ func (client *Client) Discovery() *discovery.DiscoveryClient {
 	return client.discoveryClient
 }
 
-func (client *Client) CreateNamespaceUsingGeneratedName(generatedBaseName string) (*corev1.Namespace, error) {
+func (client *Client) CreateNamespaceUsingGeneratedName(ctx context.Context, generatedBaseName string) (*corev1.Namespace, error) {
 	apiObject := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: fmt.Sprintf("%s-", generatedBaseName),
 		},
 	}
 
-	return client.clientSet.CoreV1().Namespaces().Create(context.Background(), apiObject, metav1.CreateOptions{})
+	return client.clientSet.CoreV1().Namespaces().Create(ctx, apiObject, metav1.CreateOptions{})
 }
 
-func (client *Client) DeleteNamespace(named string) error {
-	return client.clientSet.CoreV1().Namespaces().Delete(context.Background(), named, defaultResourceDeletionOptions)
+func (client *Client) DeleteNamespace(ctx context.Context, named string) error {
+	return client.clientSet.CoreV1().Namespaces().Delete(ctx, named, defaultResourceDeletionOptions)
 }
 
 func (client *Client) DefaultResourceDeletionOptions() metav1.DeleteOptions {
@@ -94,6 +97,13 @@ func (client *Client) DefaultResourceDeletionOptions() metav1.DeleteOptions {
 }
 
 func (client *Client) DetermineResourceFromGroupVersionKind(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	gvr, _, err := client.DetermineResourceAndScopeFromGroupVersionKind(gvk)
+	return gvr, err
+}
+
+// DetermineResourceAndScopeFromGroupVersionKind is DetermineResourceFromGroupVersionKind, but also returns true if
+// objects of the kind are in a Namespace, or false if they are cluster-scoped.
+func (client *Client) DetermineResourceAndScopeFromGroupVersionKind(gvk schema.GroupVersionKind) (gvr schema.GroupVersionResource, isNamespaced bool, err error) {
 	var groupVersionString string
 
 	if gvk.Group == "" {
@@ -104,7 +114,7 @@ func (client *Client) DetermineResourceFromGroupVersionKind(gvk schema.GroupVers
 
 	resources, err := client.discoveryClient.ServerResourcesForGroupVersion(groupVersionString)
 	if err != nil {
-		return schema.GroupVersionResource{}, err
+		return schema.GroupVersionResource{}, false, err
 	}
 
 	for _, resource := range resources.APIResources {
@@ -113,9 +123,98 @@ func (client *Client) DetermineResourceFromGroupVersionKind(gvk schema.GroupVers
 				Group:    gvk.Group,
 				Version:  gvk.Version,
 				Resource: resource.Name,
-			}, nil
+			}, resource.Namespaced, nil
+		}
+	}
+
+	return schema.GroupVersionResource{}, false, fmt.Errorf("could not find definition for resource %s/%s", groupVersionString, gvk.Kind)
+}
+
+// ExecInContainer runs command in the named container of a Pod, using the exec subresource, and copies its output
+// to stdout and stderr.  If containerName is empty, the Pod must have only one container.  stdin may be nil.  If
+// the command exits with a non-zero code, the returned error is a k8s.io/client-go/util/exec.ExitError.  The command
+// is abandoned if ctx is cancelled.
+func (client *Client) ExecInContainer(ctx context.Context, namespaceName string, podName string, containerName string, command []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	request := client.clientSet.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(namespaceName).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: containerName,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(client.restConfig, "POST", request.URL())
+	if err != nil {
+		return err
+	}
+
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// WriteContainerLog copies the log of the named container of a Pod to w.
+func (client *Client) WriteContainerLog(ctx context.Context, namespaceName string, podName string, containerName string, w io.Writer) error {
+	return client.writeContainerLogUsing(ctx, namespaceName, podName, &corev1.PodLogOptions{Container: containerName}, w)
+}
+
+// WritePreviousContainerLog copies the log of the previous instance of the named container of a Pod (that is, the
+// instance that ran before the container last restarted) to w.
+func (client *Client) WritePreviousContainerLog(ctx context.Context, namespaceName string, podName string, containerName string, w io.Writer) error {
+	return client.writeContainerLogUsing(ctx, namespaceName, podName, &corev1.PodLogOptions{Container: containerName, Previous: true}, w)
+}
+
+func (client *Client) writeContainerLogUsing(ctx context.Context, namespaceName string, podName string, logOptions *corev1.PodLogOptions, w io.Writer) error {
+	logStream, err := client.clientSet.CoreV1().Pods(namespaceName).GetLogs(podName, logOptions).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer logStream.Close()
+
+	_, err = io.Copy(w, logStream)
+	return err
+}
+
+// FollowContainerLog returns a stream of the log of the named container of a Pod, which remains open, delivering
+// new lines as they are written, until the container terminates or ctx is cancelled.
+func (client *Client) FollowContainerLog(ctx context.Context, namespaceName string, podName string, containerName string) (io.ReadCloser, error) {
+	return client.clientSet.CoreV1().Pods(namespaceName).GetLogs(podName, &corev1.PodLogOptions{Container: containerName, Follow: true}).Stream(ctx)
+}
+
+// PodsOwnedByJob returns the Pods that the named Job owns, using the selector of the Job to find them.
+func (client *Client) PodsOwnedByJob(ctx context.Context, namespaceName string, jobName string) ([]*corev1.Pod, error) {
+	job, err := client.clientSet.BatchV1().Jobs(namespaceName).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("Job (%s) has an invalid selector: %s", jobName, err)
+	}
+
+	podList, err := client.clientSet.CoreV1().Pods(namespaceName).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		for _, owner := range podList.Items[i].OwnerReferences {
+			if owner.UID == job.UID {
+				pods = append(pods, &podList.Items[i])
+				break
+			}
 		}
 	}
 
-	return schema.GroupVersionResource{}, fmt.Errorf("could not find definition for resource %s/%s", groupVersionString, gvk.Kind)
+	return pods, nil
 }
@@ -4,7 +4,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"regexp"
+	"strings"
+
+	"github.com/blorticus-go/jobber"
 )
 
 type ConfigVars struct {
@@ -15,16 +17,40 @@ func (v *ConfigVars) String() string {
 	return ""
 }
 
-var configVarMatcher = regexp.MustCompile(`^(\S+)=(.*)$`)
-
+// Set splits s on the first equals sign that is not inside a selector in square brackets, since a Case Matrix
+// selector (e.g., [TPS=100,WorkerThreads=2]) contains equals signs.
 func (v *ConfigVars) Set(s string) error {
-	matched := configVarMatcher.FindStringSubmatch(s)
-	if len(matched) == 0 {
-		return fmt.Errorf("must be varpath=value")
+	bracketDepth := 0
+
+	for i, r := range s {
+		switch {
+		case r == '[':
+			bracketDepth++
+		case r == ']' && bracketDepth > 0:
+			bracketDepth--
+		case r == '=' && bracketDepth == 0:
+			if varPath := s[:i]; varPath != "" && !strings.ContainsAny(varPath, " \t") {
+				v.Vars[varPath] = s[i+1:]
+				return nil
+			}
+			return fmt.Errorf("must be varpath=value")
+		}
 	}
 
-	v.Vars[matched[1]] = matched[2]
+	return fmt.Errorf("must be varpath=value")
+}
+
+// StringList is a flag that may be repeated, collecting each value.
+type StringList struct {
+	Values []string
+}
+
+func (l *StringList) String() string {
+	return ""
+}
 
+func (l *StringList) Set(s string) error {
+	l.Values = append(l.Values, s)
 	return nil
 }
 
@@ -32,6 +58,17 @@ type CommandLineArguments struct {
 	ConfigurationFilePath           string
 	KubeconfigPath                  string
 	OverridenConfigurationVariables map[string]any
+	Parallel                        uint
+	ResumeCheckpointFilePath        string
+	UnitSelectors                   []string
+	CaseSelectors                   []string
+	UnitAndCaseSelectors            []string
+	TagSelectors                    []string
+	DryRun                          bool
+	DryRunValuesTransforms          bool
+	CleanupPolicy                   string
+	ContinueOnFailure               bool
+	FollowJobLogs                   bool
 }
 
 func ParseCommandLineArguments() *CommandLineArguments {
@@ -44,9 +81,27 @@ func ParseCommandLineArguments() *CommandLineArguments {
 	flag.StringVar(&clargs.ConfigurationFilePath, "config", "./config.yaml", "YAML configuration file path")
 	flag.StringVar(&clargs.KubeconfigPath, "kubeconfig", "", "kubeconfig file path, if using")
 	flag.Var(configVars, "set", "add a configuration expansion variable of form varpath=value; may be repeated")
+	flag.StringVar(&clargs.ResumeCheckpointFilePath, "resume", "", "resume the test recorded in this checkpoint file, skipping test cases that already succeeded")
+	flag.BoolVar(&clargs.DryRun, "dry-run", false, "check that every resource template expands and is accepted by the API server (using server-side dry run), without running the test")
+	flag.BoolVar(&clargs.DryRunValuesTransforms, "dry-run-transforms", false, "with -dry-run, run values-transforms rather than skipping them; a transform can do anything an executable can, and sees only dry run responses in the Runtime values")
+	flag.StringVar(&clargs.CleanupPolicy, "cleanup", "", "when to delete created resources and the temp directory: Always, OnSuccess or Never; overrides .Test.Cleanup.Policy")
+	flag.BoolVar(&clargs.ContinueOnFailure, "continue-on-failure", false, "run the remaining test cases after a test case fails; overrides .Test.ContinueOnFailure")
+	flag.BoolVar(&clargs.FollowJobLogs, "follow", false, "print the logs of the Pods of each Job while waiting for it to complete, limited to a few lines per second for each container")
+	flag.UintVar(&clargs.Parallel, "parallel", 0, "maximum number of test cases to run at the same time; overrides .Test.Concurrency")
+
+	unitSelectors, caseSelectors, unitAndCaseSelectors, tagSelectors := &StringList{}, &StringList{}, &StringList{}, &StringList{}
+	flag.Var(unitSelectors, "unit", "run only units whose names match this glob, or regex if delimited by slashes (/regex/); may be repeated")
+	flag.Var(caseSelectors, "case", "run only cases whose names match this glob, or regex if delimited by slashes (/regex/); may be repeated")
+	flag.Var(unitAndCaseSelectors, "only", "run only cases matching <unit>/<case>, where each is a glob or /regex/; may be repeated")
+	flag.Var(tagSelectors, "tag", "run only units or cases with this tag; may be repeated")
+
 	flag.Parse()
 
 	clargs.OverridenConfigurationVariables = configVars.Vars
+	clargs.UnitSelectors = unitSelectors.Values
+	clargs.CaseSelectors = caseSelectors.Values
+	clargs.UnitAndCaseSelectors = unitAndCaseSelectors.Values
+	clargs.TagSelectors = tagSelectors.Values
 
 	return clargs
 }
@@ -61,3 +116,31 @@ func (clargs *CommandLineArguments) ResolveKubeconfigPath() (string, error) {
 
 	return clargs.KubeconfigPath, nil
 }
+
+// TestSelection returns the selection described by the -unit, -case, -only and -tag flags, or nil if none of
+// them were provided.
+func (clargs *CommandLineArguments) TestSelection() (*jobber.TestSelection, error) {
+	if len(clargs.UnitSelectors) == 0 && len(clargs.CaseSelectors) == 0 && len(clargs.UnitAndCaseSelectors) == 0 && len(clargs.TagSelectors) == 0 {
+		return nil, nil
+	}
+
+	selection := jobber.NewTestSelection()
+
+	for _, selectors := range []struct {
+		values []string
+		adder  func(string) error
+	}{
+		{clargs.UnitSelectors, selection.AddUnitPattern},
+		{clargs.CaseSelectors, selection.AddCasePattern},
+		{clargs.UnitAndCaseSelectors, selection.AddUnitAndCasePattern},
+		{clargs.TagSelectors, selection.AddTag},
+	} {
+		for _, value := range selectors.values {
+			if err := selectors.adder(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return selection, nil
+}
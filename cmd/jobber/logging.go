@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/blorticus-go/jobber"
 )
@@ -90,12 +91,125 @@ func (l *Logger) LogEventMessage(event *jobber.Event) {
 		l.SayContextually(event.Context, "Unit started")
 	case jobber.TestUnitCompletedSuccessfully:
 		l.SayContextually(event.Context, "Unit completed succesfully")
+	case jobber.TestUnitCompletedWithFailures:
+		l.SayContextually(event.Context, "Unit completed with %d failed test cases", event.OutcomesInformation.NumberOfFailures)
+	case jobber.TestCaseFailureAllowed:
+		l.SayContextually(event.Context, "Test case failed, but failure is allowed: %s", event.Error)
+	case jobber.ActionAttemptStarted:
+		l.SayContextually(event.Context, "Action [%s] attempt %d of %d started", event.AttemptInformation.ActionDescriptor, event.AttemptInformation.Attempt, event.AttemptInformation.MaximumNumberOfAttempts)
+	case jobber.ActionAttemptFailed:
+		l.SayContextually(event.Context, "Action [%s] attempt %d of %d failed, retrying in %s: %s", event.AttemptInformation.ActionDescriptor, event.AttemptInformation.Attempt, event.AttemptInformation.MaximumNumberOfAttempts, event.AttemptInformation.Backoff, event.Error)
+	case jobber.ActionTimedOut:
+		l.SayContextually(event.Context, "Action [%s] timed out: %s", event.TimeoutInformation.ActionDescriptor, event.Error)
+	case jobber.TestOutcomes:
+		l.logOutcomeMatrix(event.OutcomesInformation.Matrix)
 	case jobber.TestCaseStarted:
 		l.SayContextually(event.Context, "Test case started")
+	case jobber.TestCaseIterationStarted:
+		l.SayContextually(event.Context, "Iteration %d of %d started", event.IterationInformation.Iteration, event.IterationInformation.NumberOfIterations)
+	case jobber.TestCaseIterationCompletedSuccessfully:
+		l.SayContextually(event.Context, "Iteration %d of %d completed successfully", event.IterationInformation.Iteration, event.IterationInformation.NumberOfIterations)
+	case jobber.ActionSkipped:
+		l.SayContextually(event.Context, "Skipped action (%s) because When (%s) expanded to (%s)", event.ConditionInformation.ActionDescriptor, event.ConditionInformation.Condition, event.ConditionInformation.Expansion)
+	case jobber.ActionConditionInvalid:
+		l.SayContextually(event.Context, "Failed to evaluate When for action (%s): %s", event.ConditionInformation.ActionDescriptor, event.Error)
+	case jobber.ResourceWaitSucceeded:
+		l.SayContextually(event.Context, "Wait [%s] completed: %s", event.WaitInformation.ActionDescriptor, event.WaitInformation.Description)
+	case jobber.ResourceWaitFailed:
+		l.SayContextually(event.Context, "Wait [%s] failed: %s", event.WaitInformation.ActionDescriptor, event.Error)
+	case jobber.FilesCopiedFromPodSuccessfully:
+		l.SayContextually(event.Context, "Copied %d files from (%s) for [%s]", len(event.CopyInformation.Files), event.CopyInformation.Description, event.CopyInformation.ActionDescriptor)
+		for _, file := range event.CopyInformation.Files {
+			l.SayContextually(event.Context, "  retrieved-assets/%s", file)
+		}
+	case jobber.FileCopyFromPodFailed:
+		l.SayContextually(event.Context, "Copy [%s] failed after copying %d files: %s", event.CopyInformation.ActionDescriptor, len(event.CopyInformation.Files), event.Error)
+	case jobber.PodLogsCaptured:
+		if len(event.PodLogInformation.RestartedContainerNames) > 0 {
+			l.SayContextually(event.Context, "Captured logs of containers (%s), and of previous instances of restarted containers (%s), of Pod (%s/%s) in [%s]", strings.Join(event.PodLogInformation.ContainerNames, ", "), strings.Join(event.PodLogInformation.RestartedContainerNames, ", "), event.PodLogInformation.NamespaceName, event.PodLogInformation.PodName, event.PodLogInformation.DirectoryPath)
+		} else {
+			l.SayContextually(event.Context, "Captured logs of containers (%s) of Pod (%s/%s) in [%s]", strings.Join(event.PodLogInformation.ContainerNames, ", "), event.PodLogInformation.NamespaceName, event.PodLogInformation.PodName, event.PodLogInformation.DirectoryPath)
+		}
+	case jobber.PodLogCaptureFailed:
+		switch {
+		case event.PodLogInformation.PodName == "":
+			l.SayContextually(event.Context, "Failed to capture Pod logs in Namespace (%s): %s", event.PodLogInformation.NamespaceName, event.Error)
+		case event.PodLogInformation.ContainerName == "":
+			l.SayContextually(event.Context, "Failed to capture logs of Pod (%s/%s): %s", event.PodLogInformation.NamespaceName, event.PodLogInformation.PodName, event.Error)
+		default:
+			l.SayContextually(event.Context, "Failed to capture log of container (%s) of Pod (%s/%s): %s", event.PodLogInformation.ContainerName, event.PodLogInformation.NamespaceName, event.PodLogInformation.PodName, event.Error)
+		}
+	case jobber.ContainerLogLineReceived:
+		if event.LogLineInformation.LinesSuppressed > 0 {
+			l.SayContextually(event.Context, "%s/%s: (%d lines not shown)", event.LogLineInformation.PodName, event.LogLineInformation.ContainerName, event.LogLineInformation.LinesSuppressed)
+		} else {
+			l.SayContextually(event.Context, "%s/%s: %s", event.LogLineInformation.PodName, event.LogLineInformation.ContainerName, event.LogLineInformation.Text)
+		}
+	case jobber.ResourcePatchSuccess:
+		l.SayContextually(event.Context, "Patch [%s] modified resource kind [%s] named [%s]", event.PatchInformation.ActionDescriptor, event.PatchInformation.ResourceDetails.Kind, event.PatchInformation.ResourceDetails.Name)
+	case jobber.ResourcePatchFailure:
+		l.SayContextually(event.Context, "Patch [%s] failed: %s", event.PatchInformation.ActionDescriptor, event.Error)
+	case jobber.ResourceRestorationSuccess:
+		l.SayContextually(event.Context, "Successfully restored patched resource kind [%s] named [%s]", event.ResourceInformation.ResourceDetails.Kind, event.ResourceInformation.ResourceDetails.Name)
+	case jobber.ResourceRestorationFailure:
+		l.SayContextually(event.Context, "Failed to restore patched resource kind [%s] named [%s]: %s", event.ResourceInformation.ResourceDetails.Kind, event.ResourceInformation.ResourceDetails.Name, event.Error)
+	case jobber.ExistingResourceApplied:
+		l.SayContextually(event.Context, "Applied resource kind [%s] named [%s], which already existed", event.ResourceInformation.ResourceDetails.Kind, event.ResourceInformation.ResourceDetails.Name)
+	case jobber.ResourceBecameReady:
+		l.SayContextually(event.Context, "Resource kind [%s] named [%s] is ready", event.ResourceInformation.ResourceDetails.Kind, event.ResourceInformation.ResourceDetails.Name)
+	case jobber.SetupStarted:
+		l.SayContextually(event.Context, "Setup started")
+	case jobber.SetupCompletedSuccessfully:
+		l.SayContextually(event.Context, "Setup completed successfully")
+	case jobber.SetupFailed:
+		l.SayContextually(event.Context, "Setup failed: %s", event.Error)
+	case jobber.TeardownStarted:
+		l.SayContextually(event.Context, "Teardown started")
+	case jobber.TeardownCompletedSuccessfully:
+		l.SayContextually(event.Context, "Teardown completed successfully")
+	case jobber.TeardownFailed:
+		l.SayContextually(event.Context, "Teardown failed: %s", event.Error)
 	case jobber.TestCaseCompletedSuccessfully:
 		l.SayContextually(event.Context, "Test case completed succesfully")
 	case jobber.TestingCompletedSuccesfully:
 		l.SayContextually(event.Context, "Testing completed successfully")
+		l.logWhatWasLeftBehind(event)
+	case jobber.TestingFailed:
+		l.SayContextually(event.Context, "Testing failed")
+		l.logWhatWasLeftBehind(event)
+	case jobber.TestingCancelled:
+		l.SayContextually(event.Context, "Testing cancelled: %s", event.Error)
+		l.logWhatWasLeftBehind(event)
+	case jobber.TestCaseAlreadyCompleted:
+		l.SayContextually(event.Context, "Test case already completed successfully according to the checkpoint; skipping")
+	case jobber.ResumingFromCheckpoint:
+		l.SayContextually(event.Context, "Resuming from checkpoint file (%s)", event.FileEvent.Path)
+	case jobber.CheckpointDoesNotMatchTest:
+		l.SayContextually(event.Context, "Cannot resume from checkpoint file (%s): %s", event.FileEvent.Path, event.Error)
+	case jobber.CheckpointFileCreatedSuccessfully:
+		l.SayContextually(event.Context, "Created checkpoint file (%s)", event.FileEvent.Path)
+	case jobber.CheckpointFileUpdateFailed:
+		l.SayContextually(event.Context, "Failed to update checkpoint file (%s): %s", event.FileEvent.Path, event.Error)
+	case jobber.CheckpointFileRetained:
+		l.SayContextually(event.Context, "Checkpoint file retained; to resume the test, use: -resume %s", event.FileEvent.Path)
+	case jobber.CheckpointFileRemovedSuccessfully:
+		l.SayContextually(event.Context, "Removed checkpoint file (%s)", event.FileEvent.Path)
+	case jobber.CheckpointFileRemovalFailed:
+		l.SayContextually(event.Context, "Failed to remove checkpoint file (%s): %s", event.FileEvent.Path, event.Error)
+	case jobber.TestSelectionApplied:
+		l.SayContextually(event.Context, "Running selected units and cases (recorded in %s): %s", event.FileEvent.Path, strings.Join(strings.Split(strings.TrimSpace(event.SelectionInformation.Description), "\n"), "; "))
+	case jobber.TestSelectionFileCreationFailed:
+		l.SayContextually(event.Context, "Failed to record selection in file (%s): %s", event.FileEvent.Path, event.Error)
+	case jobber.SkippedActionNoteFailed:
+		l.SayContextually(event.Context, "Failed to note skipped action in file (%s): %s", event.FileEvent.Path, event.Error)
+	case jobber.CopiedFileRemovalFailed:
+		l.SayContextually(event.Context, "Failed to remove file (%s) copied by failed attempt: %s", event.FileEvent.Path, event.Error)
+	case jobber.ResourceDryRunSuccess:
+		l.SayContextually(event.Context, "Resource kind [%s] named [%s] accepted in dry run", event.ResourceInformation.ResourceDetails.Kind, event.ResourceInformation.ResourceDetails.Name)
+	case jobber.ActionSkippedForDryRun:
+		l.SayContextually(event.Context, "Skipping [%s] in dry run", event.DryRunInformation.ActionDescriptor)
+	case jobber.DryRunCompleted:
+		l.logDryRunSummary(event)
 	case jobber.AssetDirectoryCreatedSuccessfully:
 		l.SayContextually(event.Context, "Created directory [%s]", event.FileEvent.Path)
 	case jobber.AssetDirectoryCreationFailed:
@@ -124,3 +238,77 @@ func (l *Logger) LogEventMessage(event *jobber.Event) {
 		l.SayContextually(event.Context, "Failed to remove asset directory root at (%s): %s", event.FileEvent.Path, event.Error)
 	}
 }
+
+func (l *Logger) logDryRunSummary(event *jobber.Event) {
+	summary := event.DryRunInformation
+
+	if len(summary.Failures) == 0 {
+		l.SayContextually(event.Context, "Dry run completed: %d test cases checked, no failures", summary.NumberOfCasesChecked)
+		return
+	}
+
+	l.SayContextually(event.Context, "Dry run completed: %d test cases checked, %d failures; expanded templates retained in (%s)", summary.NumberOfCasesChecked, len(summary.Failures), summary.RetainedAssetsDirectoryPath)
+
+	for _, failure := range summary.Failures {
+		if failure.ActionDescriptor == "" {
+			l.SayContextually(failure.Context, "  %s", failure.Error)
+		} else {
+			l.SayContextually(failure.Context, "  [%s]: %s", failure.ActionDescriptor, failure.Error)
+		}
+	}
+}
+
+func (l *Logger) logWhatWasLeftBehind(event *jobber.Event) {
+	leftBehind := event.LeftBehindInformation
+	if leftBehind == nil {
+		return
+	}
+
+	if leftBehind.AssetsDirectoryPath != "" {
+		l.SayContextually(event.Context, "Temp directory left behind: %s", leftBehind.AssetsDirectoryPath)
+	}
+
+	if len(leftBehind.Resources) > 0 {
+		l.SayContextually(event.Context, "Resources left behind:")
+		for _, resource := range leftBehind.Resources {
+			if resource.NamespaceName == "" {
+				l.SayContextually(event.Context, "  %s [%s]", resource.Kind, resource.Name)
+			} else {
+				l.SayContextually(event.Context, "  %s [%s] in namespace [%s]", resource.Kind, resource.Name, resource.NamespaceName)
+			}
+		}
+	}
+}
+
+// logOutcomeMatrix prints a table with a row for each Unit and a column for each Case.
+func (l *Logger) logOutcomeMatrix(matrix *jobber.TestOutcomeMatrix) {
+	unitColumnWidth := len("Unit")
+	for _, unitName := range matrix.UnitNames() {
+		unitColumnWidth = max(unitColumnWidth, len(unitName))
+	}
+
+	caseColumnWidths := make([]int, len(matrix.CaseNames()))
+	for i, caseName := range matrix.CaseNames() {
+		caseColumnWidths[i] = len(caseName)
+		for _, unitName := range matrix.UnitNames() {
+			caseColumnWidths[i] = max(caseColumnWidths[i], len(matrix.OutcomeOf(unitName, caseName)))
+		}
+	}
+
+	header := fmt.Sprintf("%-*s", unitColumnWidth, "Unit")
+	for i, caseName := range matrix.CaseNames() {
+		header += fmt.Sprintf(" | %-*s", caseColumnWidths[i], caseName)
+	}
+
+	l.Say("Test outcomes:")
+	l.Say("%s", header)
+	l.Say("%s", strings.Repeat("-", len(header)))
+
+	for _, unitName := range matrix.UnitNames() {
+		row := fmt.Sprintf("%-*s", unitColumnWidth, unitName)
+		for i, caseName := range matrix.CaseNames() {
+			row += fmt.Sprintf(" | %-*s", caseColumnWidths[i], matrix.OutcomeOf(unitName, caseName))
+		}
+		l.Say("%s", row)
+	}
+}
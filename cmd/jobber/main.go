@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/blorticus-go/jobber"
 )
 
@@ -21,20 +23,78 @@ func main() {
 	err = config.MergeOverrideValues(clargs.OverridenConfigurationVariables)
 	logger.DieIfError(err, "failed to merge override values into configuration: %s", err)
 
+	if clargs.Parallel > 0 {
+		config.Test.Concurrency = clargs.Parallel
+	}
+
+	if clargs.ContinueOnFailure {
+		config.Test.ContinueOnFailure = true
+	}
+
+	if clargs.CleanupPolicy != "" {
+		config.Test.Cleanup.Policy, err = jobber.CleanupPolicyFromString(clargs.CleanupPolicy)
+		logger.DieIfError(err, "invalid -cleanup")
+	}
+
+	selection, err := clargs.TestSelection()
+	logger.DieIfError(err, "invalid selection")
+
+	if selection != nil && !selection.SelectsAnythingIn(config) {
+		logger.Fatalf("the selection does not match any case of any unit\n")
+	}
+
 	logger.SetContextFieldWidth(config.CharactersInLongestUnitName(), config.CharactersInLongestCaseName())
 
 	runner := jobber.NewRunner(config, client)
 
+	if selection != nil {
+		runner.WithSelection(selection)
+	}
+
+	if clargs.FollowJobLogs {
+		runner.FollowingJobLogs()
+	}
+
+	if clargs.DryRunValuesTransforms {
+		if !clargs.DryRun {
+			logger.Fatalf("-dry-run-transforms can be used only with -dry-run\n")
+		}
+		runner.RunningValuesTransformsInDryRun()
+	}
+
+	if clargs.ResumeCheckpointFilePath != "" {
+		if clargs.DryRun {
+			logger.Fatalf("-resume cannot be used with -dry-run\n")
+		}
+
+		checkpoint, err := jobber.ReadTestCheckpointFromFile(clargs.ResumeCheckpointFilePath)
+		logger.DieIfError(err)
+		runner.ResumingFrom(checkpoint)
+	}
+
+	ctx := contextCancelledOnSignal(logger)
+
 	eventChannel := make(chan *jobber.Event)
 
-	go runner.RunTest(eventChannel)
+	if clargs.DryRun {
+		go runner.DryRunTest(ctx, eventChannel)
+	} else {
+		go runner.RunTest(ctx, eventChannel)
+	}
 
-	for {
-		event := <-eventChannel
+	testingCompletedSuccessfully := false
+	for event := range eventChannel {
 		logger.LogEventMessage(event)
 
-		if event.Type == jobber.TestingCompletedSuccesfully || event.Error != nil {
-			break
+		switch {
+		case event.Type == jobber.TestingCompletedSuccesfully:
+			testingCompletedSuccessfully = true
+		case event.Type == jobber.DryRunCompleted && len(event.DryRunInformation.Failures) == 0:
+			testingCompletedSuccessfully = true
 		}
 	}
+
+	if !testingCompletedSuccessfully {
+		os.Exit(1)
+	}
 }
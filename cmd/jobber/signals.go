@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// contextCancelledOnSignal returns a context that is cancelled when SIGINT or SIGTERM is received, allowing
+// the running Test to clean up.  If either signal is received a second time, the process exits immediately.
+func contextCancelledOnSignal(logger *Logger) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signalChannel := make(chan os.Signal, 2)
+	signal.Notify(signalChannel, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		receivedSignal := <-signalChannel
+		logger.Say("Received signal (%s); cleaning up.  Repeat the signal to exit immediately.", receivedSignal)
+		cancel()
+
+		receivedSignal = <-signalChannel
+		logger.Say("Received signal (%s) again; exiting without cleaning up", receivedSignal)
+		os.Exit(1)
+	}()
+
+	return ctx
+}
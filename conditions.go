@@ -0,0 +1,68 @@
+package jobber
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+)
+
+// ActionCondition decides whether a Pipeline Action runs.  Its expression is a template which is expanded with
+// the PipelineVariables, as a resources template is, just before the action would run.
+type ActionCondition struct {
+	Expression string
+	tmpl       *template.Template
+}
+
+// NewActionConditionFromConfiguration returns the condition in the When of a Pipeline Action entry, or nil if
+// the entry has no When.
+func NewActionConditionFromConfiguration(entry *ConfigurationPipelineAction) (*ActionCondition, error) {
+	if entry.When == "" {
+		return nil, nil
+	}
+
+	tmpl, err := parseActionCondition(entry.When)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ActionCondition{
+		Expression: entry.When,
+		tmpl:       tmpl,
+	}, nil
+}
+
+func parseActionCondition(expression string) (*template.Template, error) {
+	return template.New("When").Funcs(sprig.FuncMap()).Funcs(JobberTemplateFunctions()).Parse(expression)
+}
+
+// IsSatisfiedBy expands the condition with pipelineVariables.  The expansion, with surrounding whitespace
+// removed, must be a boolean as understood by strconv.ParseBool (e.g., true, false, 1 or 0).  An empty expansion,
+// or one that refers to a value that is not set, is false.  The expansion is returned along with the result.  A
+// nil condition is always satisfied.
+func (condition *ActionCondition) IsSatisfiedBy(pipelineVariables *PipelineVariables) (isSatisfied bool, expansion string, err error) {
+	if condition == nil {
+		return true, "", nil
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := condition.tmpl.Execute(buffer, pipelineVariables); err != nil {
+		return false, "", fmt.Errorf("failed to expand When (%s): %s", condition.Expression, err)
+	}
+
+	expansion = strings.TrimSpace(buffer.String())
+
+	if expansion == "" || expansion == "<no value>" {
+		return false, expansion, nil
+	}
+
+	isSatisfied, err = strconv.ParseBool(expansion)
+	if err != nil {
+		return false, expansion, fmt.Errorf("When (%s) expanded to (%s), which is not a boolean", condition.Expression, expansion)
+	}
+
+	return isSatisfied, expansion, nil
+}
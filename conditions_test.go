@@ -0,0 +1,54 @@
+package jobber_test
+
+import (
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+)
+
+func TestActionConditions(t *testing.T) {
+	pipelineVariables := jobber.NewEmptyPipelineVariables(nil).
+		RescopedToUnitNamed("WithTelemetry").
+		WithUnitValues(map[string]any{"Sidecar": map[string]any{"Use": map[string]any{"Telemetry": true}}}).
+		RescopedToCaseNamed("100TPS").
+		WithCaseValues(map[string]any{"TPS": 100})
+
+	for _, testCase := range []struct {
+		when                  string
+		expectAnError         bool
+		expectedToBeSatisfied bool
+	}{
+		{when: "", expectedToBeSatisfied: true},
+		{when: "{{ .Values.Unit.Sidecar.Use.Telemetry }}", expectedToBeSatisfied: true},
+		{when: "{{ not .Values.Unit.Sidecar.Use.Telemetry }}", expectedToBeSatisfied: false},
+		{when: "{{ gt .Values.Case.TPS 1000 }}", expectedToBeSatisfied: false},
+		{when: `{{ eq .Context.TestUnitName "WithTelemetry" }}`, expectedToBeSatisfied: true},
+		{when: "{{ .Values.Case.Baseline }}", expectedToBeSatisfied: false},
+		{when: "  1\n", expectedToBeSatisfied: true},
+		{when: "{{ .Values.Case.TPS }}", expectAnError: true},
+	} {
+		condition, err := jobber.NewActionConditionFromConfiguration(&jobber.ConfigurationPipelineAction{Action: "resources/telemetry.yaml", When: testCase.when})
+		if err != nil {
+			t.Errorf("[%s] expected no error on NewActionConditionFromConfiguration(), got error = (%s)", testCase.when, err)
+			continue
+		}
+
+		isSatisfied, _, err := condition.IsSatisfiedBy(pipelineVariables)
+		if testCase.expectAnError {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got no error", testCase.when)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.when, err)
+		} else if isSatisfied != testCase.expectedToBeSatisfied {
+			t.Errorf("[%s] expected IsSatisfiedBy() to be (%t), got (%t)", testCase.when, testCase.expectedToBeSatisfied, isSatisfied)
+		}
+	}
+
+	if _, err := jobber.NewActionConditionFromConfiguration(&jobber.ConfigurationPipelineAction{Action: "resources/telemetry.yaml", When: "{{ .Values.Unit"}); err == nil {
+		t.Errorf("expected an error for a When that does not parse, got no error")
+	}
+}
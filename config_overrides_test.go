@@ -2,6 +2,7 @@ package jobber_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/blorticus-go/jobber"
 	"github.com/go-test/deep"
@@ -35,17 +36,17 @@ func GenerateTestConfiguration() *jobber.Configuration {
 					"PATH":       "/opt/openshift/aspen/client:/usr/bin:/bin",
 					"KUBECONFIG": "/opt/openshift/aspen/client/auth/kubeconfig",
 				},
-				ActionsInOrder: []string{
-					"resources/istio-cni.yaml",
-					"resources/nginx-producer.yaml",
-					"resources/telemetry.yaml",
-					"resources/shared-pvc.yaml",
-					"resources/jmeter-job.yaml",
-					"values-transforms/jmeter-post-job.sh",
-					"resources/jtl-processor-job.yaml",
-					"resources/prom-summary-job.yaml",
-					"resources/extractor.yaml",
-					"executables/extract-test-results.sh",
+				ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+					{Action: "resources/istio-cni.yaml"},
+					{Action: "resources/nginx-producer.yaml"},
+					{Action: "resources/telemetry.yaml"},
+					{Action: "resources/shared-pvc.yaml"},
+					{Action: "resources/jmeter-job.yaml"},
+					{Action: "values-transforms/jmeter-post-job.sh"},
+					{Action: "resources/jtl-processor-job.yaml", Name: "jtl-processor", Retries: 2, Backoff: 5 * time.Second},
+					{Action: "resources/prom-summary-job.yaml"},
+					{Action: "resources/extractor.yaml"},
+					{Action: "executables/extract-test-results.sh"},
 				},
 			},
 			Cases: []*jobber.TestCase{
@@ -109,6 +110,7 @@ func TestConfigurationMergeOverrideValues(t *testing.T) {
 		"Test.Pipeline.ExecutionEnvironment.PATH":                "/var/tmp/bar",
 		".Test.Pipeline.ExecutionEnvironment.KUBECONFIG":         "",
 		"Test.Pipeline.ActionsInOrder.[0]":                       "resources/istio-cni-revised.yaml",
+		"Test.Pipeline.ActionsInOrder.[6]":                       "resources/jtl-processor-job-revised.yaml",
 		".Test.Pipeline.ActionsInOrder.[9]":                      "resources/something",
 		"Test.Pipeline.ActionsInOrder.[10]":                      "executables/foo.sh",
 		".Test.Cases.[100TPS].Values.TPS":                        200,
@@ -127,9 +129,10 @@ func TestConfigurationMergeOverrideValues(t *testing.T) {
 	expectedConfig.Test.Pipeline.ActionDefinitionsRootDirectory = "/var/tmp/foo"
 	expectedConfig.Test.Pipeline.ExecutionEnvironment["PATH"] = "/var/tmp/bar"
 	expectedConfig.Test.Pipeline.ExecutionEnvironment["KUBECONFIG"] = ""
-	expectedConfig.Test.Pipeline.ActionsInOrder[0] = "resources/istio-cni-revised.yaml"
-	expectedConfig.Test.Pipeline.ActionsInOrder[9] = "resources/something"
-	expectedConfig.Test.Pipeline.ActionsInOrder = append(expectedConfig.Test.Pipeline.ActionsInOrder, "executables/foo.sh")
+	expectedConfig.Test.Pipeline.ActionsInOrder[0] = &jobber.ConfigurationPipelineAction{Action: "resources/istio-cni-revised.yaml"}
+	expectedConfig.Test.Pipeline.ActionsInOrder[6].Action = "resources/jtl-processor-job-revised.yaml"
+	expectedConfig.Test.Pipeline.ActionsInOrder[9] = &jobber.ConfigurationPipelineAction{Action: "resources/something"}
+	expectedConfig.Test.Pipeline.ActionsInOrder = append(expectedConfig.Test.Pipeline.ActionsInOrder, &jobber.ConfigurationPipelineAction{Action: "executables/foo.sh"})
 	expectedConfig.Test.Cases[0].Values["TPS"] = 200
 	expectedConfig.Test.Cases[0].Values["Sidecar"].(map[string]any)["WorkerThreads"] = 3
 	expectedConfig.Test.Cases[1].Values["TPS"] = 2000
@@ -145,3 +148,14 @@ func TestConfigurationMergeOverrideValues(t *testing.T) {
 	}
 
 }
+
+func TestConfigurationMergeOverrideValuesForParallelGroup(t *testing.T) {
+	config := GenerateTestConfiguration()
+	config.Test.Pipeline.ActionsInOrder[0] = &jobber.ConfigurationPipelineAction{
+		Parallel: []*jobber.ConfigurationPipelineAction{{Action: "resources/istio-cni.yaml"}, {Action: "resources/telemetry.yaml"}},
+	}
+
+	if err := config.MergeOverrideValues(map[string]any{"Test.Pipeline.ActionsInOrder.[0]": "resources/istio-cni-revised.yaml"}); err == nil {
+		t.Errorf("expected an error when overriding the Action of a Parallel group, got no error")
+	}
+}
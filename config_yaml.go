@@ -1,6 +1,7 @@
 package jobber
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
@@ -18,12 +20,19 @@ type ConfigurationDefaultNamespace struct {
 }
 
 type TestCase struct {
-	Name   string         `yaml:"Name"`
-	Values map[string]any `yaml:"Values"`
+	Name         string         `yaml:"Name"`
+	Tags         []string       `yaml:"Tags"`
+	AllowFailure bool           `yaml:"AllowFailure"`
+	Iterations   uint           `yaml:"Iterations"`
+	Values       map[string]any `yaml:"Values"`
+
+	// matrixCoordinates maps axis name to value (in string form) for a Test Case generated by a Case Matrix.
+	matrixCoordinates map[string]string
 }
 
 type TestUnit struct {
 	Name   string         `yaml:"Name"`
+	Tags   []string       `yaml:"Tags"`
 	Values map[string]any `yaml:"Values"`
 }
 
@@ -31,19 +40,169 @@ type ConfigurationAssetArchive struct {
 	FilePath string `yaml:"FilePath"`
 }
 
+// CleanupPolicy determines whether the resources created for a Test Case, and the assets directory, are removed.
+// Patched objects are restored whatever the policy.
+type CleanupPolicy string
+
+const (
+	// CleanupAlways removes them whether or not the Test Case succeeds.
+	CleanupAlways CleanupPolicy = "Always"
+
+	// CleanupOnSuccess removes them only if the Test Case succeeds, or if the Test is cancelled.  On failure, they
+	// are left in place for troubleshooting.
+	CleanupOnSuccess CleanupPolicy = "OnSuccess"
+
+	// CleanupNever never removes them.
+	CleanupNever CleanupPolicy = "Never"
+)
+
+// CleanupPolicyFromString returns the CleanupPolicy named by s, ignoring case.
+func CleanupPolicyFromString(s string) (CleanupPolicy, error) {
+	for _, policy := range []CleanupPolicy{CleanupAlways, CleanupOnSuccess, CleanupNever} {
+		if strings.EqualFold(s, string(policy)) {
+			return policy, nil
+		}
+	}
+
+	return "", fmt.Errorf("cleanup policy (%s) must be one of Always, OnSuccess or Never", s)
+}
+
+// cleansUpAfter returns true if the policy calls for cleanup after a Test Case that succeeded or not, or that was
+// cancelled.
+func (policy CleanupPolicy) cleansUpAfter(succeeded bool, cancelled bool) bool {
+	switch policy {
+	case CleanupAlways:
+		return true
+	case CleanupNever:
+		return false
+	default:
+		return succeeded || cancelled
+	}
+}
+
+// ConfigurationCleanup determines what is removed after a Test Case.  AppliedResources determines what happens to
+// objects that a resources action in Apply mode found already existing; if it is empty, they are left as applied.
+type ConfigurationCleanup struct {
+	Policy           CleanupPolicy         `yaml:"Policy"`
+	AppliedResources AppliedResourcePolicy `yaml:"AppliedResources"`
+}
+
+// ConfigurationRetryOn limits the failures of a Pipeline Action that are retried.  A failure is retryable if it
+// matches any entry in either list.
+type ConfigurationRetryOn struct {
+	// ApiErrors are Kubernetes API status reasons (e.g., Conflict, InternalError, ServerTimeout).
+	ApiErrors []string `yaml:"ApiErrors"`
+
+	// ExitCodes are exit codes of executables, values-transforms and pod-exec commands.
+	ExitCodes []int `yaml:"ExitCodes"`
+}
+
+// ConfigurationPipelineAction is an entry in .Test.Pipeline.ActionsInOrder.  In the configuration file, an entry may
+// be either the action descriptor itself (e.g., resources/jmeter-job.yaml) or a mapping in which the descriptor is
+// the value of Action.  Only the mapping form can set the other fields.  Name identifies the action within its list,
+// and is used to name its assets.  Env adds to (or overrides) .Test.Pipeline.ExecutionEnvironment and Args are
+// passed as command-line arguments, for an executables or values-transforms action.  If RetryOn is not set, every
+// failure is retryable.  Timeout, WaitTimeout and ProbeInterval override the corresponding .Test.Timeouts values for
+// this action.  If When is set, the action runs only if it expands to true (see ActionCondition).  If Follow is
+// true, for a resources action, the logs of the Pods of each Job it creates are reported while it waits for the Job
+// to complete.  Mode, for a resources action, is Create (the default) or Apply (see ResourceMode).  An entry that
+// sets Parallel is instead a group of entries that are run at the same time, and it may set only Name and DependsOn
+// besides.  If any entry in a list sets DependsOn, the list is a dependency graph: each entry runs once the entries
+// named in its DependsOn have completed, rather than after the entry that precedes it.
+type ConfigurationPipelineAction struct {
+	Action        string                         `yaml:"Action"`
+	Name          string                         `yaml:"Name"`
+	DependsOn     []string                       `yaml:"DependsOn"`
+	Env           map[string]string              `yaml:"Env"`
+	Args          []string                       `yaml:"Args"`
+	When          string                         `yaml:"When"`
+	Retries       uint                           `yaml:"Retries"`
+	Backoff       time.Duration                  `yaml:"Backoff"`
+	RetryOn       *ConfigurationRetryOn          `yaml:"RetryOn"`
+	Timeout       time.Duration                  `yaml:"Timeout"`
+	WaitTimeout   time.Duration                  `yaml:"WaitTimeout"`
+	ProbeInterval time.Duration                  `yaml:"ProbeInterval"`
+	Follow        bool                           `yaml:"Follow"`
+	Mode          string                         `yaml:"Mode"`
+	Parallel      []*ConfigurationPipelineAction `yaml:"Parallel"`
+}
+
+func (action *ConfigurationPipelineAction) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&action.Action)
+	}
+
+	type configurationPipelineActionWithoutUnmarshaler ConfigurationPipelineAction
+	return node.Decode((*configurationPipelineActionWithoutUnmarshaler)(action))
+}
+
+// ConfigurationPipeline defines the Pipeline Actions.  ActionsInOrder is run for each Test Case of each Test Unit.
+// TestSetup is run once before any Test Unit starts, and TestTeardown once after every Test Unit has finished.
+// UnitSetup is run for each Test Unit before its first Test Case starts, and UnitTeardown after its last Test Case
+// has finished.  The setup and teardown lists are optional.
 type ConfigurationPipeline struct {
-	ActionDefinitionsRootDirectory string            `yaml:"ActionDefinitionsRootDirectory"`
-	ActionsInOrder                 []string          `yaml:"ActionsInOrder"`
-	ExecutionEnvironment           map[string]string `yaml:"ExecutionEnvironment"`
+	ActionDefinitionsRootDirectory string                         `yaml:"ActionDefinitionsRootDirectory"`
+	ActionsInOrder                 []*ConfigurationPipelineAction `yaml:"ActionsInOrder"`
+	TestSetup                      []*ConfigurationPipelineAction `yaml:"TestSetup"`
+	TestTeardown                   []*ConfigurationPipelineAction `yaml:"TestTeardown"`
+	UnitSetup                      []*ConfigurationPipelineAction `yaml:"UnitSetup"`
+	UnitTeardown                   []*ConfigurationPipelineAction `yaml:"UnitTeardown"`
+	ExecutionEnvironment           map[string]string              `yaml:"ExecutionEnvironment"`
+}
+
+// configurationPipelineActionList is a list of Pipeline Action entries and its configuration key path.
+type configurationPipelineActionList struct {
+	keyPath string
+	entries []*ConfigurationPipelineAction
+}
+
+// actionLists returns each list of Pipeline Action entries, in a fixed order.
+func (p *ConfigurationPipeline) actionLists() []configurationPipelineActionList {
+	return []configurationPipelineActionList{
+		{".Test.Pipeline.ActionsInOrder", p.ActionsInOrder},
+		{".Test.Pipeline.TestSetup", p.TestSetup},
+		{".Test.Pipeline.TestTeardown", p.TestTeardown},
+		{".Test.Pipeline.UnitSetup", p.UnitSetup},
+		{".Test.Pipeline.UnitTeardown", p.UnitTeardown},
+	}
+}
+
+// ConfigurationTimeouts are the default timeouts for every Pipeline Action.  Action limits how long an action
+// may run, Wait limits how long an action waits for each created resource to reach its expected state, and
+// ProbeInterval is how often the resource is checked while waiting.  A zero value means that Action is not
+// limited, or that the built-in default for the kind of resource applies to Wait and ProbeInterval.
+type ConfigurationTimeouts struct {
+	Action        time.Duration `yaml:"Action"`
+	Wait          time.Duration `yaml:"Wait"`
+	ProbeInterval time.Duration `yaml:"ProbeInterval"`
 }
 
 type ConfigurationTest struct {
-	AssetArchive     *ConfigurationAssetArchive     `yaml:"AssetArchive"`
-	DefaultNamespace *ConfigurationDefaultNamespace `yaml:"DefaultNamespace"`
-	GlobalValues     map[string]any                 `yaml:"GlobalValues"`
-	Pipeline         *ConfigurationPipeline         `yaml:"Pipeline"`
-	Cases            []*TestCase                    `yaml:"Cases"`
-	Units            []*TestUnit                    `yaml:"Units"`
+	AssetArchive      *ConfigurationAssetArchive     `yaml:"AssetArchive"`
+	CaseMatrix        *ConfigurationCaseMatrix       `yaml:"CaseMatrix"`
+	Cleanup           *ConfigurationCleanup          `yaml:"Cleanup"`
+	Concurrency       uint                           `yaml:"Concurrency"`
+	ContinueOnFailure bool                           `yaml:"ContinueOnFailure"`
+	DefaultNamespace  *ConfigurationDefaultNamespace `yaml:"DefaultNamespace"`
+	Iterations        uint                           `yaml:"Iterations"`
+	GlobalValues      map[string]any                 `yaml:"GlobalValues"`
+	Pipeline          *ConfigurationPipeline         `yaml:"Pipeline"`
+	Cases             []*TestCase                    `yaml:"Cases"`
+	Timeouts          *ConfigurationTimeouts         `yaml:"Timeouts"`
+	Units             []*TestUnit                    `yaml:"Units"`
+}
+
+// IterationsFor returns the number of times testCase is run for each Test Unit.  That is the Iterations of
+// testCase if it is set, otherwise the Iterations of the Test if that is set, otherwise 1.
+func (t *ConfigurationTest) IterationsFor(testCase *TestCase) uint {
+	switch {
+	case testCase.Iterations > 0:
+		return testCase.Iterations
+	case t.Iterations > 0:
+		return t.Iterations
+	default:
+		return 1
+	}
 }
 
 type Configuration struct {
@@ -71,10 +230,28 @@ func (c *Configuration) validate() error {
 		return fmt.Errorf(".Test.AssetArchive.FilePath must exist and cannot be the empty string")
 	}
 
+	if c.Test.Cleanup != nil && c.Test.Cleanup.Policy != "" {
+		if _, err := CleanupPolicyFromString(string(c.Test.Cleanup.Policy)); err != nil {
+			return fmt.Errorf(".Test.Cleanup.Policy is invalid: %s", err)
+		}
+	}
+
+	if c.Test.Cleanup != nil && c.Test.Cleanup.AppliedResources != "" {
+		if _, err := AppliedResourcePolicyFromString(string(c.Test.Cleanup.AppliedResources)); err != nil {
+			return fmt.Errorf(".Test.Cleanup.AppliedResources is invalid: %s", err)
+		}
+	}
+
 	if c.Test.DefaultNamespace == nil {
 		return fmt.Errorf(".Test.DefaultNamespace must be defined")
 	}
 
+	if c.Test.Timeouts != nil {
+		if err := requireNonNegativeDurations(".Test.Timeouts", []string{"Action", "Wait", "ProbeInterval"}, c.Test.Timeouts.Action, c.Test.Timeouts.Wait, c.Test.Timeouts.ProbeInterval); err != nil {
+			return err
+		}
+	}
+
 	if c.Test.DefaultNamespace.Basename == "" {
 		return fmt.Errorf(".Test.DefaultNamespace.Basename must be defined and cannot be the empty string")
 	}
@@ -91,17 +268,235 @@ func (c *Configuration) validate() error {
 		return fmt.Errorf(".Test.Pipeline.ActionsInOrder must have at least one entry")
 	}
 
-	for pipelineEntryIndex, value := range c.Test.Pipeline.ActionsInOrder {
-		s := strings.Split(value, "/")
-		if len(s) != 2 {
-			return fmt.Errorf(".Test.Pipeline.ActionsInOrder[%d] must be of format <type>/<target>", pipelineEntryIndex)
+	for _, actionList := range c.Test.Pipeline.actionLists() {
+		if err := validatePipelineActionEntries(actionList.keyPath, actionList.entries); err != nil {
+			return err
 		}
-		switch s[0] {
-		case "resources":
-		case "values-transforms":
-		case "executables":
-		default:
-			return fmt.Errorf(".Test.Pipeline.ActionsInOrder[%d] type indicator [%s] is not understood", pipelineEntryIndex, s[0])
+	}
+
+	for _, testUnit := range c.Test.Units {
+		if isReservedAssetDirectoryName(testUnit.Name) {
+			return fmt.Errorf(".Test.Units name (%s) is reserved", testUnit.Name)
+		}
+	}
+
+	for _, testCase := range c.Test.Cases {
+		if isReservedAssetDirectoryName(testCase.Name) {
+			return fmt.Errorf(".Test.Cases name (%s) is reserved", testCase.Name)
+		}
+	}
+
+	return nil
+}
+
+var pipelineActionNamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+// validatePipelineActionEntries validates the Pipeline Action entries of the list at keyPath.
+func validatePipelineActionEntries(keyPath string, entries []*ConfigurationPipelineAction) error {
+	actionNames := make(map[string]bool)
+
+	for pipelineEntryIndex, entry := range entries {
+		entryKeyPath := fmt.Sprintf("%s[%d]", keyPath, pipelineEntryIndex)
+
+		if entry == nil {
+			return fmt.Errorf("%s must not be empty", entryKeyPath)
+		}
+
+		if entry.Parallel == nil {
+			if err := validatePipelineActionEntry(entryKeyPath, entry, actionNames); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(*entry, ConfigurationPipelineAction{Name: entry.Name, DependsOn: entry.DependsOn, Parallel: entry.Parallel}) {
+			return fmt.Errorf("%s is a Parallel group, so it may set only Name, DependsOn and Parallel", entryKeyPath)
+		}
+
+		if len(entry.Parallel) == 0 {
+			return fmt.Errorf("%s.Parallel must have at least one entry", entryKeyPath)
+		}
+
+		if err := validatePipelineActionName(entryKeyPath, entry.Name, actionNames); err != nil {
+			return err
+		}
+
+		for memberIndex, member := range entry.Parallel {
+			memberKeyPath := fmt.Sprintf("%s.Parallel[%d]", entryKeyPath, memberIndex)
+
+			if member == nil {
+				return fmt.Errorf("%s must not be empty", memberKeyPath)
+			}
+
+			if member.Parallel != nil {
+				return fmt.Errorf("%s cannot be a Parallel group", memberKeyPath)
+			}
+
+			if len(member.DependsOn) > 0 {
+				return fmt.Errorf("%s cannot set DependsOn, since it runs when its group does", memberKeyPath)
+			}
+
+			if strings.HasPrefix(member.Action, "values-transforms/") {
+				return fmt.Errorf("%s cannot be a values-transforms action, since it would replace the values used by the other members of the group", memberKeyPath)
+			}
+
+			if err := validatePipelineActionEntry(memberKeyPath, member, actionNames); err != nil {
+				return err
+			}
+		}
+	}
+
+	return validatePipelineActionDependencies(keyPath, entries)
+}
+
+// validatePipelineActionDependencies checks that each name in the DependsOn of an entry in entries (the list at
+// keyPath) is the Name of another entry in the list, and that there are no dependency cycles.
+func validatePipelineActionDependencies(keyPath string, entries []*ConfigurationPipelineAction) error {
+	indexByName := make(map[string]int)
+	for entryIndex, entry := range entries {
+		if entry.Name != "" {
+			indexByName[entry.Name] = entryIndex
+		}
+	}
+
+	for entryIndex, entry := range entries {
+		for _, dependencyName := range entry.DependsOn {
+			dependencyIndex, isAnEntry := indexByName[dependencyName]
+			if !isAnEntry {
+				return fmt.Errorf("%s[%d].DependsOn names (%s), which is not the Name of an entry in the list", keyPath, entryIndex, dependencyName)
+			}
+			if dependencyIndex == entryIndex {
+				return fmt.Errorf("%s[%d].DependsOn names the entry itself", keyPath, entryIndex)
+			}
+		}
+	}
+
+	const (
+		notVisited = iota
+		beingVisited
+		visited
+	)
+
+	visitState := make([]int, len(entries))
+
+	var visit func(entryIndex int, path []string) error
+	visit = func(entryIndex int, path []string) error {
+		path = append(path, entries[entryIndex].Name)
+
+		switch visitState[entryIndex] {
+		case visited:
+			return nil
+		case beingVisited:
+			return fmt.Errorf("%s has a dependency cycle: %s", keyPath, strings.Join(path, " -> "))
+		}
+
+		visitState[entryIndex] = beingVisited
+		for _, dependencyName := range entries[entryIndex].DependsOn {
+			if err := visit(indexByName[dependencyName], path); err != nil {
+				return err
+			}
+		}
+		visitState[entryIndex] = visited
+
+		return nil
+	}
+
+	for entryIndex := range entries {
+		if err := visit(entryIndex, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validatePipelineActionEntry validates the Pipeline Action entry at keyPath, which is not a Parallel group.
+// actionNames holds the names of the entries already validated in the same list, and the name of this entry is
+// added to it.
+func validatePipelineActionEntry(keyPath string, entry *ConfigurationPipelineAction, actionNames map[string]bool) error {
+	s := strings.Split(entry.Action, "/")
+	if len(s) < 2 {
+		return fmt.Errorf("%s must be of format <type>/<target>", keyPath)
+	}
+	for _, targetPathElement := range s[1:] {
+		if targetPathElement == "" || targetPathElement == "." || targetPathElement == ".." {
+			return fmt.Errorf("%s target (%s) must be a relative path without empty, . or .. elements", keyPath, strings.Join(s[1:], "/"))
+		}
+	}
+	switch s[0] {
+	case "resources", "waits", "pod-exec", "copy-from-pod", "patch":
+		if len(entry.Env) > 0 || len(entry.Args) > 0 {
+			return fmt.Errorf("%s is a %s action, so it cannot have Env or Args", keyPath, s[0])
+		}
+	case "values-transforms":
+	case "executables":
+	default:
+		return fmt.Errorf("%s type indicator [%s] is not understood", keyPath, s[0])
+	}
+
+	if entry.Follow && s[0] != "resources" {
+		return fmt.Errorf("%s is a %s action, so it cannot set Follow", keyPath, s[0])
+	}
+
+	if entry.Mode != "" {
+		if s[0] != "resources" {
+			return fmt.Errorf("%s is a %s action, so it cannot set Mode", keyPath, s[0])
+		}
+		if _, err := ResourceModeFromString(entry.Mode); err != nil {
+			return fmt.Errorf("%s.Mode is invalid: %s", keyPath, err)
+		}
+	}
+
+	if err := validatePipelineActionName(keyPath, entry.Name, actionNames); err != nil {
+		return err
+	}
+
+	if err := requireNonNegativeDurations(keyPath, []string{"Backoff", "Timeout", "WaitTimeout", "ProbeInterval"}, entry.Backoff, entry.Timeout, entry.WaitTimeout, entry.ProbeInterval); err != nil {
+		return err
+	}
+
+	if entry.When != "" {
+		if _, err := parseActionCondition(entry.When); err != nil {
+			return fmt.Errorf("%s.When is invalid: %s", keyPath, err)
+		}
+	}
+
+	if entry.RetryOn != nil {
+		for _, reason := range entry.RetryOn.ApiErrors {
+			if !isKnownApiErrorReason(reason) {
+				return fmt.Errorf("%s.RetryOn.ApiErrors entry (%s) is not a known API error reason", keyPath, reason)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePipelineActionName checks that name, if it is set, is well-formed and is not in actionNames, then adds
+// it to actionNames.
+func validatePipelineActionName(keyPath string, name string, actionNames map[string]bool) error {
+	if name == "" {
+		return nil
+	}
+
+	if !pipelineActionNamePattern.MatchString(name) {
+		return fmt.Errorf("%s.Name (%s) may contain only letters, digits, '.', '_' and '-'", keyPath, name)
+	}
+
+	if actionNames[name] {
+		return fmt.Errorf("%s.Name (%s) is used by another entry", keyPath, name)
+	}
+
+	actionNames[name] = true
+	return nil
+}
+
+// requireNonNegativeDurations returns an error naming the first of durations that is negative.  fieldNames are the
+// names of durations, in the same order, under the configuration key at keyPath.
+func requireNonNegativeDurations(keyPath string, fieldNames []string, durations ...time.Duration) error {
+	for i, d := range durations {
+		if d < 0 {
+			return fmt.Errorf("%s.%s must not be negative", keyPath, fieldNames[i])
 		}
 	}
 
@@ -113,6 +508,34 @@ func (c *Configuration) expandDefaults() {
 		c.Test.GlobalValues = make(map[string]any)
 	}
 
+	if c.Test.Cleanup == nil {
+		c.Test.Cleanup = &ConfigurationCleanup{}
+	}
+
+	if c.Test.Cleanup.Policy == "" {
+		c.Test.Cleanup.Policy = CleanupOnSuccess
+	} else {
+		c.Test.Cleanup.Policy, _ = CleanupPolicyFromString(string(c.Test.Cleanup.Policy))
+	}
+
+	if c.Test.Cleanup.AppliedResources != "" {
+		c.Test.Cleanup.AppliedResources, _ = AppliedResourcePolicyFromString(string(c.Test.Cleanup.AppliedResources))
+	}
+
+	if c.Test.Concurrency == 0 {
+		c.Test.Concurrency = 1
+	}
+
+	for _, actionList := range c.Test.Pipeline.actionLists() {
+		for _, pipelineEntry := range actionList.entries {
+			for _, actionEntry := range append([]*ConfigurationPipelineAction{pipelineEntry}, pipelineEntry.Parallel...) {
+				if actionEntry.Retries > 0 && actionEntry.Backoff == 0 {
+					actionEntry.Backoff = defaultActionRetryBackoff
+				}
+			}
+		}
+	}
+
 	for _, testCase := range c.Test.Cases {
 		if testCase.Values == nil {
 			testCase.Values = make(map[string]any)
@@ -137,7 +560,7 @@ func (c *Configuration) MergeOverrideValues(overrideValues map[string]any) error
 			return fmt.Errorf("no such configuration key (%s); must start with .Test or Test", overrideKey)
 		}
 
-		keyStack := strings.Split(overrideKey, ".")
+		keyStack := splitOverrideKey(overrideKey)
 		if len(keyStack) < 3 {
 			return fmt.Errorf("no such configuration key (%s)", overrideKey)
 		}
@@ -183,6 +606,28 @@ func (c *Configuration) MergeOverrideValues(overrideValues map[string]any) error
 	return nil
 }
 
+// splitOverrideKey splits overrideKey on dots, except for dots inside a selector in square brackets, so that a
+// selector may contain a Test Case name or Case Matrix axis name with a dot in it.
+func splitOverrideKey(overrideKey string) []string {
+	keyStack := make([]string, 0)
+	bracketDepth := 0
+	start := 0
+
+	for i, r := range overrideKey {
+		switch {
+		case r == '[':
+			bracketDepth++
+		case r == ']' && bracketDepth > 0:
+			bracketDepth--
+		case r == '.' && bracketDepth == 0:
+			keyStack = append(keyStack, overrideKey[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(keyStack, overrideKey[start:])
+}
+
 func (c *Configuration) assetArchiveOverride(subKeyStack []string, overrideValue any, originalOverrideKey string) error {
 	if len(subKeyStack) != 1 {
 		return fmt.Errorf("no such configuration key (%s)", originalOverrideKey)
@@ -263,9 +708,14 @@ func (c *Configuration) pipelineOverride(subKeyStack []string, overrideValue any
 
 		switch {
 		case listSelectorIndex < len(c.Test.Pipeline.ActionsInOrder):
-			c.Test.Pipeline.ActionsInOrder[listSelectorIndex] = overrideValueToString(overrideValue)
+			// Only the descriptor is replaced, so that the rest of the entry (e.g., its Name and Retries) is kept
+			entry := c.Test.Pipeline.ActionsInOrder[listSelectorIndex]
+			if entry.Parallel != nil {
+				return fmt.Errorf("the entry selected by (%s) is a Parallel group, which has no Action to override", originalOverrideKey)
+			}
+			entry.Action = overrideValueToString(overrideValue)
 		case listSelectorIndex == len(c.Test.Pipeline.ActionsInOrder):
-			c.Test.Pipeline.ActionsInOrder = append(c.Test.Pipeline.ActionsInOrder, overrideValueToString(overrideValue))
+			c.Test.Pipeline.ActionsInOrder = append(c.Test.Pipeline.ActionsInOrder, &ConfigurationPipelineAction{Action: overrideValueToString(overrideValue)})
 		default:
 			return fmt.Errorf("list selector index (%d) out of range in (%s)", listSelectorIndex, originalOverrideKey)
 		}
@@ -292,16 +742,26 @@ func (c *Configuration) casesOverride(subKeyStack []string, overrideValue any, o
 		return fmt.Errorf("must select override for (%s)", originalOverrideKey)
 	}
 
-	var matchingCase *TestCase = nil
+	matchingCases := make([]*TestCase, 0, 1)
 
 	for _, testCase := range c.Test.Cases {
 		if testCase.Name == caseNameSelectorAsAString {
-			matchingCase = testCase
+			matchingCases = append(matchingCases, testCase)
 			break
 		}
 	}
 
-	if matchingCase == nil {
+	// A selector of the form [name=value,...] that is not the name of a Test Case selects every Test Case
+	// generated by the Case Matrix with those axis values
+	if len(matchingCases) == 0 && strings.Contains(caseNameSelectorAsAString, "=") {
+		for _, testCase := range c.Test.Cases {
+			if testCase.matchesMatrixCoordinates(caseNameSelectorAsAString) {
+				matchingCases = append(matchingCases, testCase)
+			}
+		}
+	}
+
+	if len(matchingCases) == 0 {
 		return fmt.Errorf("for the configuration key (%s), there is no such case named (%s)", originalOverrideKey, caseNameSelectorAsAString)
 	}
 
@@ -313,7 +773,13 @@ func (c *Configuration) casesOverride(subKeyStack []string, overrideValue any, o
 		return fmt.Errorf("cannot override non-leaf configuration key (%s)", originalOverrideKey)
 	}
 
-	return setConfigurationKeyValueInMultiLevelMap(matchingCase.Values, subKeyStack[2:], overrideValue, originalOverrideKey)
+	for _, matchingCase := range matchingCases {
+		if err := setConfigurationKeyValueInMultiLevelMap(matchingCase.Values, subKeyStack[2:], overrideValue, originalOverrideKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (c *Configuration) unitsOverride(subKeyStack []string, overrideValue any, originalOverrideKey string) error {
@@ -366,6 +832,10 @@ func ReadConfigurationYamlFromReader(r io.Reader) (*Configuration, error) {
 		return nil, err
 	}
 
+	if err := c.expandCaseMatrix(); err != nil {
+		return nil, err
+	}
+
 	if err := c.validate(); err != nil {
 		return nil, err
 	}
@@ -453,6 +923,17 @@ func ReadConfigurationYamlFromFile(filePath string) (*Configuration, error) {
 	return ReadConfigurationYamlFromReader(fh)
 }
 
+// Digest returns a digest of c, so that Tests with the same effective configuration (including overrides) can be
+// recognized.
+func (c *Configuration) Digest() (string, error) {
+	encoded, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode configuration: %s", err)
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(encoded)), nil
+}
+
 func (c *Configuration) CharactersInLongestCaseName() uint {
 	longest := 0
 	for _, c := range c.Test.Cases {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/blorticus-go/jobber"
 	"github.com/go-test/deep"
@@ -84,6 +85,10 @@ Test:
 `,
 		expectedStruct: &jobber.Configuration{
 			Test: &jobber.ConfigurationTest{
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
 				AssetArchive: &jobber.ConfigurationAssetArchive{
 					FilePath: "/opt/performance-test/asm/$(target-version)/$(date)/test-result.tar.gz",
 				},
@@ -101,16 +106,16 @@ Test:
 				},
 				Pipeline: &jobber.ConfigurationPipeline{
 					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
-					ActionsInOrder: []string{
-						"resources/nginx-producer.yaml",
-						"resources/telemetry.yaml",
-						"values-transforms/post-asm.sh",
-						"resources/shared-pvc.yaml",
-						"resources/jmeter-job.yaml",
-						"resources/jtl-processor-job.yaml",
-						"resources/container-resources-job.yaml",
-						"resources/retrieval-pod.yaml",
-						"executables/extract-data.sh",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+						{Action: "resources/telemetry.yaml"},
+						{Action: "values-transforms/post-asm.sh"},
+						{Action: "resources/shared-pvc.yaml"},
+						{Action: "resources/jmeter-job.yaml"},
+						{Action: "resources/jtl-processor-job.yaml"},
+						{Action: "resources/container-resources-job.yaml"},
+						{Action: "resources/retrieval-pod.yaml"},
+						{Action: "executables/extract-data.sh"},
 					},
 				},
 				Cases: []*jobber.TestCase{
@@ -195,6 +200,10 @@ Test:
 `,
 		expectedStruct: &jobber.Configuration{
 			Test: &jobber.ConfigurationTest{
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
 				AssetArchive: &jobber.ConfigurationAssetArchive{
 					FilePath: "/opt/performance-test/asm/$(target-version)/$(date)/test-result.tar.gz",
 				},
@@ -204,16 +213,16 @@ Test:
 				GlobalValues: map[string]any{},
 				Pipeline: &jobber.ConfigurationPipeline{
 					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
-					ActionsInOrder: []string{
-						"resources/nginx-producer.yaml",
-						"resources/telemetry.yaml",
-						"values-transforms/post-asm.sh",
-						"resources/shared-pvc.yaml",
-						"resources/jmeter-job.yaml",
-						"resources/jtl-processor-job.yaml",
-						"resources/container-resources-job.yaml",
-						"resources/retrieval-pod.yaml",
-						"executables/extract-data.sh",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+						{Action: "resources/telemetry.yaml"},
+						{Action: "values-transforms/post-asm.sh"},
+						{Action: "resources/shared-pvc.yaml"},
+						{Action: "resources/jmeter-job.yaml"},
+						{Action: "resources/jtl-processor-job.yaml"},
+						{Action: "resources/container-resources-job.yaml"},
+						{Action: "resources/retrieval-pod.yaml"},
+						{Action: "executables/extract-data.sh"},
 					},
 				},
 				Cases: []*jobber.TestCase{
@@ -302,6 +311,10 @@ Test:
 `,
 		expectedStruct: &jobber.Configuration{
 			Test: &jobber.ConfigurationTest{
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
 				AssetArchive: &jobber.ConfigurationAssetArchive{
 					FilePath: "/opt/performance-test/asm/$(target-version)/$(date)/test-result.tar.gz",
 				},
@@ -319,16 +332,16 @@ Test:
 				},
 				Pipeline: &jobber.ConfigurationPipeline{
 					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
-					ActionsInOrder: []string{
-						"resources/nginx-producer.yaml",
-						"resources/telemetry.yaml",
-						"values-transforms/post-asm.sh",
-						"resources/shared-pvc.yaml",
-						"resources/jmeter-job.yaml",
-						"resources/jtl-processor-job.yaml",
-						"resources/container-resources-job.yaml",
-						"resources/retrieval-pod.yaml",
-						"executables/extract-data.sh",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+						{Action: "resources/telemetry.yaml"},
+						{Action: "values-transforms/post-asm.sh"},
+						{Action: "resources/shared-pvc.yaml"},
+						{Action: "resources/jmeter-job.yaml"},
+						{Action: "resources/jtl-processor-job.yaml"},
+						{Action: "resources/container-resources-job.yaml"},
+						{Action: "resources/retrieval-pod.yaml"},
+						{Action: "executables/extract-data.sh"},
 					},
 				},
 				Cases: []*jobber.TestCase{
@@ -411,6 +424,10 @@ Test:
 `,
 		expectedStruct: &jobber.Configuration{
 			Test: &jobber.ConfigurationTest{
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
 				AssetArchive: &jobber.ConfigurationAssetArchive{
 					FilePath: "/opt/performance-test/asm/$(target-version)/$(date)/test-result.tar.gz",
 				},
@@ -428,16 +445,16 @@ Test:
 				},
 				Pipeline: &jobber.ConfigurationPipeline{
 					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
-					ActionsInOrder: []string{
-						"resources/nginx-producer.yaml",
-						"resources/telemetry.yaml",
-						"values-transforms/post-asm.sh",
-						"resources/shared-pvc.yaml",
-						"resources/jmeter-job.yaml",
-						"resources/jtl-processor-job.yaml",
-						"resources/container-resources-job.yaml",
-						"resources/retrieval-pod.yaml",
-						"executables/extract-data.sh",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+						{Action: "resources/telemetry.yaml"},
+						{Action: "values-transforms/post-asm.sh"},
+						{Action: "resources/shared-pvc.yaml"},
+						{Action: "resources/jmeter-job.yaml"},
+						{Action: "resources/jtl-processor-job.yaml"},
+						{Action: "resources/container-resources-job.yaml"},
+						{Action: "resources/retrieval-pod.yaml"},
+						{Action: "executables/extract-data.sh"},
 					},
 				},
 				Cases: []*jobber.TestCase{
@@ -916,6 +933,1169 @@ Test:
   Units: []
 `,
 	},
+	{
+		caseName: "Concurrency is read when provided",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  Concurrency: 4
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/nginx-producer.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 4,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Cleanup policy is read when provided",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  Cleanup:
+    Policy: never
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/nginx-producer.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupNever,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "ContinueOnFailure and AllowFailure are read when provided",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  ContinueOnFailure: true
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/nginx-producer.yaml
+  Cases:
+  - Name: 100TPS
+  - Name: 5000TPS
+    AllowFailure: true
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency:       1,
+				ContinueOnFailure: true,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+					{
+						Name:         "5000TPS",
+						AllowFailure: true,
+						Values:       map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Invalid cleanup policy",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  Cleanup:
+    Policy: Sometimes
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/nginx-producer.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Pipeline entries may set a retry policy",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/nginx-producer.yaml
+      - Action: resources/jmeter-job.yaml
+        Retries: 3
+        Backoff: 10s
+        RetryOn:
+          ApiErrors: [ServerTimeout, Conflict]
+      - Action: executables/extract-data.sh
+        Retries: 1
+        RetryOn:
+          ExitCodes: [75]
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+						{
+							Action:  "resources/jmeter-job.yaml",
+							Retries: 3,
+							Backoff: 10 * time.Second,
+							RetryOn: &jobber.ConfigurationRetryOn{
+								ApiErrors: []string{"ServerTimeout", "Conflict"},
+							},
+						},
+						{
+							Action:  "executables/extract-data.sh",
+							Retries: 1,
+							Backoff: time.Second,
+							RetryOn: &jobber.ConfigurationRetryOn{
+								ExitCodes: []int{75},
+							},
+						},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Timeouts are read for the Test and for Pipeline entries",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Timeouts:
+    Action: 30m
+    Wait: 5m
+    ProbeInterval: 2s
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/jmeter-job.yaml
+        Timeout: 2h
+        WaitTimeout: 90m
+        ProbeInterval: 30s
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{
+							Action:        "resources/jmeter-job.yaml",
+							Timeout:       2 * time.Hour,
+							WaitTimeout:   90 * time.Minute,
+							ProbeInterval: 30 * time.Second,
+						},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Timeouts: &jobber.ConfigurationTimeouts{
+					Action:        30 * time.Minute,
+					Wait:          5 * time.Minute,
+					ProbeInterval: 2 * time.Second,
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Negative timeout",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Timeouts:
+    Wait: -5m
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Iterations are read for the Test and for Test Cases",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Iterations: 3
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  - Name: 5000TPS
+    Iterations: 5
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				Iterations:   3,
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/jmeter-job.yaml"},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+					{
+						Name:       "5000TPS",
+						Iterations: 5,
+						Values:     map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Unknown API error reason in RetryOn",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/jmeter-job.yaml
+        Retries: 3
+        RetryOn:
+          ApiErrors: [WebhookTimeout]
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Setup and teardown Pipelines are read",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    TestSetup:
+      - resources/metrics-collector.yaml
+    TestTeardown:
+      - executables/collect-metrics.sh
+    UnitSetup:
+      - Action: resources/telemetry.yaml
+        Retries: 2
+    UnitTeardown:
+      - executables/dump-telemetry-config.sh
+    ActionsInOrder:
+      - resources/jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/jmeter-job.yaml"},
+					},
+					TestSetup: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/metrics-collector.yaml"},
+					},
+					TestTeardown: []*jobber.ConfigurationPipelineAction{
+						{Action: "executables/collect-metrics.sh"},
+					},
+					UnitSetup: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/telemetry.yaml", Retries: 2, Backoff: time.Second},
+					},
+					UnitTeardown: []*jobber.ConfigurationPipelineAction{
+						{Action: "executables/dump-telemetry-config.sh"},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Invalid type indicator in UnitTeardown",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    UnitTeardown:
+      - scripts/dump-telemetry-config.sh
+    ActionsInOrder:
+      - resources/jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Test Unit with a reserved name",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: _setup
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "When that does not parse",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/telemetry.yaml
+        When: "{{ .Values.Unit.Sidecar.Use.Telemetry"
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Structured entries with Name, Env, Args and a nested target",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/load/jmeter-job.yaml
+        Name: jmeter
+        Timeout: 15m
+      - Action: executables/reporting/extract-test-results.sh
+        Env:
+          REPORT_FORMAT: csv
+        Args: [--summary]
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{
+							Action:  "resources/load/jmeter-job.yaml",
+							Name:    "jmeter",
+							Timeout: 15 * time.Minute,
+						},
+						{
+							Action: "executables/reporting/extract-test-results.sh",
+							Env:    map[string]string{"REPORT_FORMAT": "csv"},
+							Args:   []string{"--summary"},
+						},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Args on a resources entry",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/jmeter-job.yaml
+        Args: [--summary]
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Duplicate entry Name",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/jmeter-job.yaml
+        Name: jmeter
+      - Action: resources/jmeter-job.yaml
+        Name: jmeter
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Target that leaves the action definitions root directory",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/../jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Parallel group",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Parallel:
+        - resources/nginx-producer.yaml
+        - Action: resources/jmeter-job.yaml
+          Retries: 2
+      - executables/extract-test-results.sh
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{
+							Parallel: []*jobber.ConfigurationPipelineAction{
+								{Action: "resources/nginx-producer.yaml"},
+								{Action: "resources/jmeter-job.yaml", Retries: 2, Backoff: time.Second},
+							},
+						},
+						{Action: "executables/extract-test-results.sh"},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "values-transforms in a Parallel group",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Parallel:
+        - resources/nginx-producer.yaml
+        - values-transforms/jmeter-post-job.sh
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Nested Parallel group",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Parallel:
+        - resources/nginx-producer.yaml
+        - Parallel:
+          - resources/jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Parallel group that also sets Action",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/nginx-producer.yaml
+        Parallel:
+        - resources/jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "DependsOn",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Name: pvc
+        Action: resources/shared-pvc.yaml
+      - Name: producer
+        Action: resources/nginx-producer.yaml
+        DependsOn: [pvc]
+      - Name: jmeter
+        Action: resources/jmeter-job.yaml
+        DependsOn: [pvc]
+      - Action: executables/extract-test-results.sh
+        DependsOn: [producer, jmeter]
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/shared-pvc.yaml", Name: "pvc"},
+						{Action: "resources/nginx-producer.yaml", Name: "producer", DependsOn: []string{"pvc"}},
+						{Action: "resources/jmeter-job.yaml", Name: "jmeter", DependsOn: []string{"pvc"}},
+						{Action: "executables/extract-test-results.sh", DependsOn: []string{"producer", "jmeter"}},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "DependsOn unknown action",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Name: producer
+        Action: resources/nginx-producer.yaml
+      - Action: resources/jmeter-job.yaml
+        DependsOn: [consumer]
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "DependsOn cycle",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Name: pvc
+        Action: resources/shared-pvc.yaml
+        DependsOn: [jmeter]
+      - Name: producer
+        Action: resources/nginx-producer.yaml
+        DependsOn: [pvc]
+      - Name: jmeter
+        Action: resources/jmeter-job.yaml
+        DependsOn: [producer]
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "waits action with Args",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/nginx-producer.yaml
+      - Action: waits/nginx-hpa-scaled.yaml
+        Args: [3]
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Follow",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/nginx-producer.yaml
+      - Action: resources/jmeter-job.yaml
+        Follow: true
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy: jobber.CleanupOnSuccess,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+						{Action: "resources/jmeter-job.yaml", Follow: true},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Follow on executables action",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: executables/extract-test-results.sh
+        Follow: true
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "patch action with Env",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: patch/istio-access-log.yaml
+        Env:
+          LOG: /dev/stdout
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "Mode Apply with AppliedResources Revert",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  Cleanup:
+    AppliedResources: revert
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/peer-authentication.yaml
+        Mode: Apply
+      - resources/jmeter-job.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectedStruct: &jobber.Configuration{
+			Test: &jobber.ConfigurationTest{
+				AssetArchive: &jobber.ConfigurationAssetArchive{
+					FilePath: "/tmp/test-result.tar.gz",
+				},
+				Cleanup: &jobber.ConfigurationCleanup{
+					Policy:           jobber.CleanupOnSuccess,
+					AppliedResources: jobber.AppliedResourcesRevert,
+				},
+				Concurrency: 1,
+				DefaultNamespace: &jobber.ConfigurationDefaultNamespace{
+					Basename: "asm-perftest-",
+				},
+				GlobalValues: map[string]any{},
+				Pipeline: &jobber.ConfigurationPipeline{
+					ActionDefinitionsRootDirectory: "/home/vwells/pipeline",
+					ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/peer-authentication.yaml", Mode: "Apply"},
+						{Action: "resources/jmeter-job.yaml"},
+					},
+				},
+				Cases: []*jobber.TestCase{
+					{
+						Name:   "100TPS",
+						Values: map[string]any{},
+					},
+				},
+				Units: []*jobber.TestUnit{
+					{
+						Name:   "NoSidecar",
+						Values: map[string]any{},
+					},
+				},
+			},
+		},
+	},
+	{
+		caseName: "Mode on executables action",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: executables/extract-test-results.sh
+        Mode: Apply
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "invalid Mode",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - Action: resources/peer-authentication.yaml
+        Mode: Replace
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
+	{
+		caseName: "invalid AppliedResources",
+		configAsString: `---
+Test:
+  AssetArchive:
+    FilePath: /tmp/test-result.tar.gz
+  Cleanup:
+    AppliedResources: Delete
+  DefaultNamespace:
+    Basename: asm-perftest-
+  Pipeline:
+    ActionDefinitionsRootDirectory: /home/vwells/pipeline
+    ActionsInOrder:
+      - resources/peer-authentication.yaml
+  Cases:
+  - Name: 100TPS
+  Units:
+  - Name: NoSidecar
+`,
+		expectAnError: true,
+	},
 }
 
 func TestConfigs(t *testing.T) {
@@ -0,0 +1,321 @@
+package jobber
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PodCopyDefinition is the target of a copy-from-pod action, after template expansion.  Each of Paths, which must
+// be absolute, is copied from Container of Pod, along with its contents if it is a directory.  If Namespace is
+// empty, the default Namespace is used.  If Container is empty, the Pod must have only one container.  A copied
+// file keeps its path, relative to the container root, under Destination, which is a relative path within the
+// retrieved assets directory of the Test Case (or that directory itself, if Destination is empty).
+//
+// A file is copied only if it matches one of Include (or Include is empty) and does not match any of Exclude.  A
+// glob matches a file if it matches, as understood by path.Match, either the path of the file relative to the
+// container root (e.g., results/run-1/summary.json) or its base name (e.g., *.json).  If SizeLimit is set (e.g.,
+// 100Mi), the copy fails once the files copied exceed it in total.
+type PodCopyDefinition struct {
+	Pod         string   `yaml:"Pod"`
+	Namespace   string   `yaml:"Namespace"`
+	Container   string   `yaml:"Container"`
+	Paths       []string `yaml:"Paths"`
+	Include     []string `yaml:"Include"`
+	Exclude     []string `yaml:"Exclude"`
+	SizeLimit   string   `yaml:"SizeLimit"`
+	Destination string   `yaml:"Destination"`
+
+	sizeLimitInBytes int64
+}
+
+// ParsePodCopyDefinition decodes and validates a PodCopyDefinition from yamlBytes.
+func ParsePodCopyDefinition(yamlBytes []byte) (*PodCopyDefinition, error) {
+	definition := new(PodCopyDefinition)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(yamlBytes))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(definition); err != nil {
+		return nil, fmt.Errorf("failed to decode copy-from-pod definition: %s", err)
+	}
+
+	if definition.Pod == "" {
+		return nil, fmt.Errorf("copy-from-pod definition must have a Pod")
+	}
+
+	if len(definition.Paths) == 0 {
+		return nil, fmt.Errorf("copy-from-pod definition must have at least one of Paths")
+	}
+
+	for _, p := range definition.Paths {
+		if !path.IsAbs(p) || path.Clean(p) == "/" {
+			return nil, fmt.Errorf("copy-from-pod definition Paths entry (%s) must be an absolute path other than /", p)
+		}
+	}
+
+	for _, glob := range append(append([]string{}, definition.Include...), definition.Exclude...) {
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("copy-from-pod definition glob (%s) is invalid: %s", glob, err)
+		}
+	}
+
+	if definition.SizeLimit != "" {
+		quantity, err := resource.ParseQuantity(definition.SizeLimit)
+		if err != nil {
+			return nil, fmt.Errorf("copy-from-pod definition SizeLimit (%s) is invalid: %s", definition.SizeLimit, err)
+		}
+		if definition.sizeLimitInBytes = quantity.Value(); definition.sizeLimitInBytes <= 0 {
+			return nil, fmt.Errorf("copy-from-pod definition SizeLimit (%s) must be greater than zero", definition.SizeLimit)
+		}
+	}
+
+	if definition.Destination != "" {
+		if !isARelativePathWithinDirectory(definition.Destination) {
+			return nil, fmt.Errorf("copy-from-pod definition Destination (%s) must be a relative path without .. elements", definition.Destination)
+		}
+	}
+
+	return definition, nil
+}
+
+// tarCommand returns the command, run in the container, that writes a tar archive of Paths to stdout.
+func (definition *PodCopyDefinition) tarCommand() []string {
+	command := []string{"tar", "cf", "-", "-C", "/"}
+	for _, p := range definition.Paths {
+		command = append(command, strings.TrimPrefix(path.Clean(p), "/"))
+	}
+
+	return command
+}
+
+// Description describes what definition copies (e.g., "extractor:/results").
+func (definition *PodCopyDefinition) Description() string {
+	source := definition.Pod
+	if definition.Container != "" {
+		source = fmt.Sprintf("%s/%s", definition.Pod, definition.Container)
+	}
+
+	return fmt.Sprintf("%s:%s", source, strings.Join(definition.Paths, ","))
+}
+
+func (definition *PodCopyDefinition) shouldCopy(filePath string) bool {
+	matchesAny := func(globs []string) bool {
+		for _, glob := range globs {
+			if matched, _ := path.Match(glob, filePath); matched {
+				return true
+			}
+			if matched, _ := path.Match(glob, path.Base(filePath)); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(definition.Include) > 0 && !matchesAny(definition.Include) {
+		return false
+	}
+
+	return !matchesAny(definition.Exclude)
+}
+
+// ExtractArchive unpacks the files in the tar archive read from archive that definition selects into
+// destinationDirectoryPath, creating directories as needed.  Only regular files are extracted; other entries (e.g.,
+// symbolic links) are ignored, as are entries whose paths would lead outside destinationDirectoryPath.  The
+// paths of the extracted files, relative to destinationDirectoryPath, are returned in the order in which they were
+// extracted, even if an error occurs.
+func (definition *PodCopyDefinition) ExtractArchive(archive io.Reader, destinationDirectoryPath string) (extractedFiles []string, err error) {
+	extractedFiles = make([]string, 0)
+	tarReader := tar.NewReader(archive)
+	var bytesExtracted int64
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return extractedFiles, nil
+		}
+		if err != nil {
+			return extractedFiles, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		entryPath := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if header.Typeflag != tar.TypeReg || !isARelativePathWithinDirectory(entryPath) || !definition.shouldCopy(entryPath) {
+			continue
+		}
+
+		if definition.sizeLimitInBytes > 0 && bytesExtracted+header.Size > definition.sizeLimitInBytes {
+			return extractedFiles, fmt.Errorf("copying (%s) would exceed the SizeLimit (%s)", entryPath, definition.SizeLimit)
+		}
+
+		if err := extractFileFromArchive(tarReader, header, filepath.Join(destinationDirectoryPath, filepath.FromSlash(entryPath))); err != nil {
+			return extractedFiles, err
+		}
+
+		bytesExtracted += header.Size
+		extractedFiles = append(extractedFiles, entryPath)
+	}
+}
+
+func extractFileFromArchive(tarReader *tar.Reader, header *tar.Header, filePath string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
+		return fmt.Errorf("failed to create directory for (%s): %s", filePath, err)
+	}
+
+	fileHandle, err := os.OpenFile(filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, header.FileInfo().Mode().Perm()|0600)
+	if err != nil {
+		return fmt.Errorf("failed to create (%s): %s", filePath, err)
+	}
+
+	_, err = io.Copy(fileHandle, tarReader)
+	if closeErr := fileHandle.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write (%s): %w", filePath, err)
+	}
+
+	return nil
+}
+
+func isARelativePathWithinDirectory(p string) bool {
+	if path.IsAbs(p) {
+		return false
+	}
+
+	for _, element := range strings.Split(p, "/") {
+		if element == ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removeFilesCopiedByFailedAttempt removes the copiedFiles (relative to retrievedAssetsDirectoryPath) of an attempt
+// that is about to be retried, so that the retrieved assets hold only the files of the attempt that is kept.  A file
+// that cannot be removed is reported.
+func removeFilesCopiedByFailedAttempt(retrievedAssetsDirectoryPath string, copiedFiles []string, eventHandler *eventHandler, testUnit *TestUnit, testCase *TestCase) {
+	for _, copiedFile := range copiedFiles {
+		copiedFilePath := filepath.Join(retrievedAssetsDirectoryPath, filepath.FromSlash(copiedFile))
+		if err := os.Remove(copiedFilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			eventHandler.sayThatCopiedFileCouldNotBeRemoved(copiedFilePath, err, testUnit, testCase)
+		}
+	}
+}
+
+// runCopyFromPod expands the copy-from-pod definition template, then streams a tar archive of the paths it names
+// out of the container, extracting the selected files into retrievedAssetsDirectoryPath.
+func (action *PipelineAction) runCopyFromPod(ctx context.Context, pipelineVariables *PipelineVariables, retrievedAssetsDirectoryPath string, client *Client, eventChannel chan<- *ActionEvent) {
+	if retrievedAssetsDirectoryPath == "" {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: fmt.Errorf("no retrieved assets directory is set for (%s)", action.ActionFullyQualifiedPath),
+		}
+		return
+	}
+
+	templateBuffer, err := action.expandDefinitionTemplate(pipelineVariables)
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: err,
+		}
+		return
+	}
+
+	eventChannel <- &ActionEvent{
+		Type:                   TemplateExpanded,
+		ExpandedTemplateBuffer: templateBuffer,
+	}
+
+	definition, err := ParsePodCopyDefinition(templateBuffer.Bytes())
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: fmt.Errorf("in (%s): %s", action.ActionFullyQualifiedPath, err),
+		}
+		return
+	}
+
+	namespaceName := definition.Namespace
+	if namespaceName == "" {
+		namespaceName = pipelineVariables.Runtime.DefaultNamespace.Name
+	}
+
+	destinationDirectoryPath := filepath.Join(retrievedAssetsDirectoryPath, filepath.FromSlash(definition.Destination))
+
+	copiedFiles, err := definition.copyFromContainer(ctx, client, namespaceName, destinationDirectoryPath)
+	for i := range copiedFiles {
+		copiedFiles[i] = path.Join(definition.Destination, copiedFiles[i])
+	}
+
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:        AnErrorOccurred,
+			Error:       fmt.Errorf("failed to copy (%s): %w", definition.Description(), explainedIfActionTimedOut(ctx, err)),
+			CopiedFiles: copiedFiles,
+		}
+		return
+	}
+
+	eventChannel <- &ActionEvent{
+		Type:        FilesCopiedFromPod,
+		Description: definition.Description(),
+		CopiedFiles: copiedFiles,
+	}
+
+	eventChannel <- &ActionEvent{
+		Type: ActionCompletedSuccessfully,
+	}
+}
+
+// copyFromContainer runs tar in the container, extracting its output as it arrives.  If extraction fails (e.g.,
+// because the SizeLimit is exceeded), the command is abandoned.
+func (definition *PodCopyDefinition) copyFromContainer(ctx context.Context, client *Client, namespaceName string, destinationDirectoryPath string) ([]string, error) {
+	copyCtx, cancelCopy := context.WithCancel(ctx)
+	defer cancelCopy()
+
+	archiveReader, archiveWriter := io.Pipe()
+	tarStderr := new(bytes.Buffer)
+	execErrorChannel := make(chan error, 1)
+
+	go func() {
+		err := client.ExecInContainer(copyCtx, namespaceName, definition.Pod, definition.Container, definition.tarCommand(), nil, archiveWriter, tarStderr)
+		archiveWriter.CloseWithError(err)
+		execErrorChannel <- err
+	}()
+
+	copiedFiles, extractErr := definition.ExtractArchive(archiveReader, destinationDirectoryPath)
+	if extractErr != nil {
+		cancelCopy()
+		archiveReader.CloseWithError(extractErr)
+	} else {
+		// tar may pad the archive beyond its end marker
+		io.Copy(io.Discard, archiveReader)
+	}
+
+	execErr := <-execErrorChannel
+
+	switch {
+	case extractErr != nil && !errors.Is(extractErr, execErr):
+		return copiedFiles, extractErr
+	case execErr != nil:
+		if stderr := strings.TrimSpace(tarStderr.String()); stderr != "" {
+			return copiedFiles, fmt.Errorf("%w: %s", execErr, stderr)
+		}
+		return copiedFiles, execErr
+	default:
+		return copiedFiles, nil
+	}
+}
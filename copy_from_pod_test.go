@@ -0,0 +1,152 @@
+package jobber_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+)
+
+func tarArchiveOf(t *testing.T, entries []*tar.Header, contents map[string]string) *bytes.Buffer {
+	archive := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(archive)
+
+	for _, header := range entries {
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(contents[header.Name]))
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("failed to write tar header for (%s): %s", header.Name, err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			tarWriter.Write([]byte(contents[header.Name]))
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %s", err)
+	}
+
+	return archive
+}
+
+func TestPodCopyExtractArchive(t *testing.T) {
+	contents := map[string]string{
+		"results/run.jtl":              "timeStamp,elapsed\n",
+		"results/jmeter.log":           "INFO started\n",
+		"results/summary/summary.json": `{"tps": 100}`,
+		"results/summary/debug.json":   `{"debug": true}`,
+		"../escape.jtl":                "outside",
+	}
+
+	entries := func() []*tar.Header {
+		return []*tar.Header{
+			{Name: "results/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "results/run.jtl", Typeflag: tar.TypeReg, Mode: 0644},
+			{Name: "results/jmeter.log", Typeflag: tar.TypeReg, Mode: 0644},
+			{Name: "results/summary/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "results/summary/summary.json", Typeflag: tar.TypeReg, Mode: 0644},
+			{Name: "results/summary/debug.json", Typeflag: tar.TypeReg, Mode: 0644},
+			{Name: "results/latest.jtl", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+			{Name: "../escape.jtl", Typeflag: tar.TypeReg, Mode: 0644},
+		}
+	}
+
+	for _, testCase := range []struct {
+		testName               string
+		definition             string
+		expectAnError          bool
+		expectedExtractedFiles []string
+	}{
+		{
+			testName:               "everything",
+			definition:             "Pod: extractor\nPaths: [/results]\n",
+			expectedExtractedFiles: []string{"results/run.jtl", "results/jmeter.log", "results/summary/summary.json", "results/summary/debug.json"},
+		},
+		{
+			testName:               "include and exclude",
+			definition:             "Pod: extractor\nPaths: [/results]\nInclude: ['*.jtl', '*.json']\nExclude: [results/summary/debug.*]\n",
+			expectedExtractedFiles: []string{"results/run.jtl", "results/summary/summary.json"},
+		},
+		{
+			testName:               "size limit exceeded",
+			definition:             "Pod: extractor\nPaths: [/results]\nSizeLimit: 20\n",
+			expectAnError:          true,
+			expectedExtractedFiles: []string{"results/run.jtl"},
+		},
+	} {
+		definition, err := jobber.ParsePodCopyDefinition([]byte(testCase.definition))
+		if err != nil {
+			t.Errorf("[%s] expected no error from ParsePodCopyDefinition(), got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		destinationDirectoryPath := t.TempDir()
+		extractedFiles, err := definition.ExtractArchive(tarArchiveOf(t, entries(), contents), destinationDirectoryPath)
+
+		if testCase.expectAnError && err == nil {
+			t.Errorf("[%s] expected an error, got no error", testCase.testName)
+		} else if !testCase.expectAnError && err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+		}
+
+		if diff := deep.Equal(extractedFiles, testCase.expectedExtractedFiles); diff != nil {
+			t.Errorf("[%s] %v", testCase.testName, diff)
+		}
+
+		for _, extractedFile := range extractedFiles {
+			fileContents, err := os.ReadFile(filepath.Join(destinationDirectoryPath, extractedFile))
+			if err != nil {
+				t.Errorf("[%s] expected to read extracted file (%s), got error = (%s)", testCase.testName, extractedFile, err)
+			} else if string(fileContents) != contents[extractedFile] {
+				t.Errorf("[%s] expected contents of (%s) to be (%s), got (%s)", testCase.testName, extractedFile, contents[extractedFile], string(fileContents))
+			}
+		}
+
+		if _, err := os.Lstat(filepath.Join(destinationDirectoryPath, "results", "latest.jtl")); err == nil {
+			t.Errorf("[%s] expected symbolic link not to be extracted", testCase.testName)
+		}
+	}
+}
+
+func TestPodCopyDefinitionValidation(t *testing.T) {
+	for _, testCase := range []struct {
+		testName   string
+		definition string
+	}{
+		{"no Pod", "Paths: [/results]\n"},
+		{"no Paths", "Pod: extractor\n"},
+		{"relative path", "Pod: extractor\nPaths: [results]\n"},
+		{"root path", "Pod: extractor\nPaths: [/]\n"},
+		{"invalid glob", "Pod: extractor\nPaths: [/results]\nInclude: ['[']\n"},
+		{"invalid size limit", "Pod: extractor\nPaths: [/results]\nSizeLimit: lots\n"},
+		{"destination outside retrieved assets", "Pod: extractor\nPaths: [/results]\nDestination: ../elsewhere\n"},
+	} {
+		if _, err := jobber.ParsePodCopyDefinition([]byte(testCase.definition)); err == nil {
+			t.Errorf("[%s] expected an error, got no error", testCase.testName)
+		}
+	}
+}
+
+func TestCopyFromPodActionRequiresARetrievedAssetsDirectory(t *testing.T) {
+	action, err := jobber.PipelineActionFromStringDescriptor("copy-from-pod/results", "testing_assets")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	// The Context, which a values-transform may change, is not where files are copied
+	variables := jobber.NewEmptyPipelineVariables(nil).AndTestCaseRetrievedAssetsDirectoryAt(t.TempDir())
+
+	actionEventChannel := make(chan *jobber.ActionEvent)
+	go action.Run(context.Background(), variables, &jobber.PipelineExecutionEnvironment{}, nil, actionEventChannel)
+
+	event := <-actionEventChannel
+	if event.Type != jobber.AnErrorOccurred {
+		t.Fatalf("expected AnErrorOccurred event when no retrieved assets directory is set, got event type (%d)", event.Type)
+	}
+}
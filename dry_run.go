@@ -0,0 +1,171 @@
+package jobber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DryRunFailure describes an error encountered while dry-running a Test Case.
+type DryRunFailure struct {
+	Context EventContext
+
+	// ActionDescriptor is the descriptor of the Pipeline Action that failed.  It is empty if the failure did not
+	// come from a Pipeline Action (e.g., the default Namespace could not be created).
+	ActionDescriptor string
+	Error            error
+}
+
+// RejectedInDryRunError is the error of a resources action, in a dry run, when the API server rejected any of the
+// resources that it submitted.  The other resources are still submitted, so Rejections holds every rejection.
+type RejectedInDryRunError struct {
+	Rejections []error
+}
+
+func (e *RejectedInDryRunError) Error() string {
+	if len(e.Rejections) == 1 {
+		return e.Rejections[0].Error()
+	}
+	return fmt.Sprintf("%d resources not accepted in dry run", len(e.Rejections))
+}
+
+func (e *RejectedInDryRunError) Unwrap() []error {
+	return e.Rejections
+}
+
+// DryRunTest checks, for each selected Test Case of each selected Test Unit, that every resource template expands
+// and that the API server accepts every resource it describes.  Each Test Case gets a real default Namespace, but
+// the resources are submitted with server-side dry run, so nothing else is created.  Every resource of a template is
+// submitted, even if an earlier one is rejected.  Every other action is skipped, except values-transforms if
+// RunningValuesTransformsInDryRun() was called.  Each Test Case is checked once, however many iterations it has.
+// The setup and teardown Pipelines are not checked.  Unlike RunTest, a failure does not stop the Test Case or the
+// Test: every failure is reported in the final event.  No archive is created.  The assets directory, which contains
+// the expanded templates, is retained only if there were failures.  eventChannel is closed when DryRunTest returns.
+func (runner *Runner) DryRunTest(ctx context.Context, eventChannel chan<- *Event) {
+	defer close(eventChannel)
+
+	eventHandler := &eventHandler{eventChannel}
+	assetsDirectoryManager := NewContextualAssetsDirectoryManager()
+
+	outcome := assetsDirectoryManager.CreateTestAssetsRootDirectory()
+	if eventHandler.explainAssetCreationOutcome(outcome, nil, nil); outcome.DirectoryCreationFailureError != nil {
+		return
+	}
+
+	templateExpansionVariables := NewEmptyPipelineVariables(runner.client).WithGlobalValues(runner.config.Test.GlobalValues)
+
+	testCasePipeline, err := NewPipelineFromConfiguration(runner.config.Test)
+	if err != nil {
+		eventHandler.sayThatPipelineDefinitionIsInvalid(err)
+		return
+	}
+
+	failures := make([]*DryRunFailure, 0)
+	numberOfCasesChecked := 0
+
+UnitLoop:
+	for _, testUnit := range runner.config.Test.Units {
+		testCasesToRun := runner.testCasesToRunFor(testUnit, nil, eventHandler)
+		if len(testCasesToRun) == 0 {
+			continue
+		}
+
+		eventHandler.sayThatUnitStarted(testUnit)
+
+		outcome := assetsDirectoryManager.CreateTestUnitDirectory(testUnit)
+		if eventHandler.explainAssetCreationOutcome(outcome, testUnit, nil); outcome.DirectoryCreationFailureError != nil {
+			failures = append(failures, &DryRunFailure{Context: EventContextFor(testUnit, nil), Error: outcome.DirectoryCreationFailureError})
+			continue
+		}
+
+		unitVariables := templateExpansionVariables.RescopedToUnitNamed(testUnit.Name).WithUnitValues(testUnit.Values)
+		aTestCaseHasFailed := false
+
+		for _, testCase := range testCasesToRun {
+			testCaseFailures := runner.dryRunTestCase(ctx, testCasePipeline.Copy(), unitVariables, eventHandler, assetsDirectoryManager, testUnit, testCase)
+			if ctx.Err() != nil {
+				break UnitLoop
+			}
+
+			numberOfCasesChecked++
+
+			if len(testCaseFailures) > 0 {
+				failures = append(failures, testCaseFailures...)
+				aTestCaseHasFailed = true
+			}
+		}
+
+		if !aTestCaseHasFailed {
+			eventHandler.sayThatUnitCompletedSuccessfully(testUnit)
+		}
+	}
+
+	if ctx.Err() != nil || len(failures) == 0 {
+		runner.removeAssetsDirectory(assetsDirectoryManager, eventHandler)
+	}
+
+	if ctx.Err() != nil {
+		eventHandler.sayThatTestingWasCancelled(ctx.Err(), []*K8sResourceInformation{}, "")
+		return
+	}
+
+	eventHandler.sayThatDryRunCompleted(numberOfCasesChecked, failures, assetsDirectoryManager.TestRootAssetDirectoryPath())
+}
+
+// dryRunTestCase dry-runs the Pipeline for a single Test Case of a Test Unit, continuing past failed Pipeline
+// Actions, and returns the failures.  The default Namespace is always deleted.
+func (runner *Runner) dryRunTestCase(ctx context.Context, testCasePipeline *Pipeline, unitVariables *PipelineVariables, eventHandler *eventHandler, assetsDirectoryManager *ContextualAssetsDirectoryManager, testUnit *TestUnit, testCase *TestCase) []*DryRunFailure {
+	eventHandler.sayThatCaseStarted(testUnit, testCase)
+
+	outcome := assetsDirectoryManager.CreateTestCaseDirectories(testUnit, testCase, 1)
+	if eventHandler.explainAssetCreationOutcome(outcome, testUnit, testCase); outcome.DirectoryCreationFailureError != nil {
+		return []*DryRunFailure{{Context: EventContextFor(testUnit, testCase), Error: outcome.DirectoryCreationFailureError}}
+	}
+
+	resourceTracker := NewCreatedResourceTracker()
+	testCasePaths := assetsDirectoryManager.TestCaseAssetsDirectoryPathsFor(testUnit, testCase)
+
+	templateExpansionVariables := unitVariables.
+		RescopedToCaseNamed(testCase.Name).
+		WithCaseValues(testCase.Values).
+		ForIteration(1).
+		AndTestCaseRetrievedAssetsDirectoryAt(testCasePaths.RetrievedAssets)
+
+	nsObject, err := runner.createDefaultNamespace(ctx, templateExpansionVariables, resourceTracker)
+	if eventHandler.explainAttemptToCreateDefaultNamespace(nsObject, EventContextFor(testUnit, testCase), err); err != nil {
+		runner.deleteTrackedResources(resourceTracker, eventHandler, testUnit, testCase)
+		return []*DryRunFailure{{Context: EventContextFor(testUnit, testCase), Error: err}}
+	}
+
+	templateExpansionVariables.AndUsingDefaultNamespaceNamed(nsObject.Name)
+
+	failures := make([]*DryRunFailure, 0)
+	executionEnvironment := &PipelineExecutionEnvironment{
+		EnvironmentalVariables:       runner.config.Test.Pipeline.ExecutionEnvironment,
+		DryRun:                       true,
+		ValuesTransformsInDryRun:     runner.transformsInDryRun,
+		RetrievedAssetsDirectoryPath: testCasePaths.RetrievedAssets,
+	}
+
+	for _, failure := range runner.runPipeline(ctx, testCasePipeline, true, templateExpansionVariables, executionEnvironment, resourceTracker, eventHandler, testCasePaths, testUnit, testCase) {
+		var rejectedInDryRun *RejectedInDryRunError
+		if errors.As(failure.err, &rejectedInDryRun) {
+			for _, rejection := range rejectedInDryRun.Rejections {
+				failures = append(failures, &DryRunFailure{Context: EventContextFor(testUnit, testCase), ActionDescriptor: failure.action.Descriptor, Error: rejection})
+			}
+			continue
+		}
+
+		failures = append(failures, &DryRunFailure{Context: EventContextFor(testUnit, testCase), ActionDescriptor: failure.action.Descriptor, Error: failure.err})
+	}
+
+	if err := runner.deleteTrackedResources(resourceTracker, eventHandler, testUnit, testCase); err != nil {
+		failures = append(failures, &DryRunFailure{Context: EventContextFor(testUnit, testCase), Error: err})
+	}
+
+	if len(failures) == 0 && ctx.Err() == nil {
+		eventHandler.sayThatCaseCompletedSuccessfully(testUnit, testCase)
+	}
+
+	return failures
+}
@@ -1,13 +1,26 @@
 package jobber
 
 import (
+	"bytes"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
 type StringRetriever func() string
 
+// stringRetrieverFor returns a StringRetriever for the contents of buffer.  If buffer is nil, the retriever
+// returns the empty string.
+func stringRetrieverFor(buffer *bytes.Buffer) StringRetriever {
+	return func() string {
+		if buffer == nil {
+			return ""
+		}
+		return buffer.String()
+	}
+}
+
 type K8sResourceInformation struct {
 	Kind          string
 	Name          string
@@ -63,6 +76,52 @@ const (
 	JobFailedToComplete
 	ArchiveFileCreatedSuccessfully
 	ArchiveFileCreationFailed
+	TestingCancelled
+	TestCaseAlreadyCompleted
+	ResumingFromCheckpoint
+	CheckpointFileCreatedSuccessfully
+	CheckpointFileUpdateFailed
+	CheckpointFileRetained
+	CheckpointFileRemovedSuccessfully
+	CheckpointFileRemovalFailed
+	TestSelectionApplied
+	TestSelectionFileCreationFailed
+	ResourceDryRunSuccess
+	ActionSkippedForDryRun
+	DryRunCompleted
+	TestingFailed
+	TestCaseFailureAllowed
+	TestUnitCompletedWithFailures
+	TestOutcomes
+	ActionAttemptStarted
+	ActionAttemptFailed
+	ActionTimedOut
+	TestCaseIterationStarted
+	TestCaseIterationCompletedSuccessfully
+	SetupStarted
+	SetupCompletedSuccessfully
+	SetupFailed
+	TeardownStarted
+	TeardownCompletedSuccessfully
+	TeardownFailed
+	ActionSkipped
+	ActionConditionInvalid
+	ResourceWaitSucceeded
+	ResourceWaitFailed
+	FilesCopiedFromPodSuccessfully
+	FileCopyFromPodFailed
+	PodLogsCaptured
+	PodLogCaptureFailed
+	ContainerLogLineReceived
+	ResourcePatchSuccess
+	ResourcePatchFailure
+	ResourceRestorationSuccess
+	ResourceRestorationFailure
+	ExistingResourceApplied
+	ResourceBecameReady
+	SkippedActionNoteFailed
+	CopiedFileRemovalFailed
+	CheckpointDoesNotMatchTest
 )
 
 type ResourceEvent struct {
@@ -97,6 +156,111 @@ type FileEvent struct {
 	Path string
 }
 
+type SelectionEvent struct {
+	// Description describes the selectors that were applied, one kind of selector per line.
+	Description string
+}
+
+type DryRunEvent struct {
+	// ActionDescriptor is the Pipeline Action that was skipped.  This is set only when the event type is
+	// ActionSkippedForDryRun.
+	ActionDescriptor string
+
+	// NumberOfCasesChecked, Failures and RetainedAssetsDirectoryPath are set only when the event type is
+	// DryRunCompleted.  RetainedAssetsDirectoryPath is empty if there were no failures.
+	NumberOfCasesChecked        int
+	Failures                    []*DryRunFailure
+	RetainedAssetsDirectoryPath string
+}
+
+// LeftBehindEvent describes what remains when testing ends.  It is set only on the final event.
+type LeftBehindEvent struct {
+	// Resources that were created but not deleted, in order of creation within each Test Case.
+	Resources []*K8sResourceInformation
+
+	// AssetsDirectoryPath is the assets root directory, or the empty string if it was removed.
+	AssetsDirectoryPath string
+}
+
+type OutcomesEvent struct {
+	// Matrix is set only when the event type is TestOutcomes.
+	Matrix *TestOutcomeMatrix
+
+	// NumberOfFailures is set only when the event type is TestUnitCompletedWithFailures.
+	NumberOfFailures int
+}
+
+type AttemptEvent struct {
+	ActionDescriptor string
+
+	// Attempt counts from 1.  MaximumNumberOfAttempts includes the first attempt.
+	Attempt                 uint
+	MaximumNumberOfAttempts uint
+
+	// Backoff is the delay before the next attempt.  It is set only when the event type is ActionAttemptFailed.
+	Backoff time.Duration
+}
+
+type TimeoutEvent struct {
+	ActionDescriptor string
+}
+
+type IterationEvent struct {
+	// Iteration counts from 1.
+	Iteration          uint
+	NumberOfIterations uint
+}
+
+type ConditionEvent struct {
+	ActionDescriptor string
+	Condition        string
+
+	// Expansion is the expanded condition.  It is set only when the event type is ActionSkipped.
+	Expansion string
+}
+
+type WaitEvent struct {
+	ActionDescriptor string
+
+	// Description describes what was waited for.  It is set only when the event type is ResourceWaitSucceeded.
+	Description string
+}
+
+type CopyEvent struct {
+	ActionDescriptor string
+
+	// Description describes what was copied.  It is set only when the event type is FilesCopiedFromPodSuccessfully.
+	Description string
+
+	// Files are the paths of the files that were copied, relative to the retrieved assets directory.  If the copy
+	// failed, they are the files that were copied before it failed.
+	Files []string
+}
+
+type PatchEvent struct {
+	ActionDescriptor string
+
+	// ResourceDetails describes the patched object.  It is nil when the event type is ResourcePatchFailure.
+	ResourceDetails *K8sResourceInformation
+}
+
+type PodLogEvent struct {
+	NamespaceName string
+
+	// PodName is empty if the Pods of a Job could not be found.  ContainerName is set only when the event type is
+	// PodLogCaptureFailed, and is empty if the failure was not specific to a container.
+	PodName       string
+	ContainerName string
+
+	// ContainerNames, RestartedContainerNames and DirectoryPath are set only when the event type is PodLogsCaptured.
+	// The log of each container is in <DirectoryPath>/<container-name>.log.  RestartedContainerNames are those of
+	// the containers that had restarted, the log of whose previous instance is in
+	// <DirectoryPath>/<container-name>.previous.log.
+	ContainerNames          []string
+	RestartedContainerNames []string
+	DirectoryPath           string
+}
+
 type Event struct {
 	Type                       EventType
 	Context                    EventContext
@@ -104,6 +268,19 @@ type Event struct {
 	ValuesTransformInformation *ValuesTransformEvent
 	ExecuableInformation       *ExecutableEvent
 	FileEvent                  *FileEvent
+	SelectionInformation       *SelectionEvent
+	DryRunInformation          *DryRunEvent
+	LeftBehindInformation      *LeftBehindEvent
+	OutcomesInformation        *OutcomesEvent
+	AttemptInformation         *AttemptEvent
+	TimeoutInformation         *TimeoutEvent
+	IterationInformation       *IterationEvent
+	ConditionInformation       *ConditionEvent
+	WaitInformation            *WaitEvent
+	CopyInformation            *CopyEvent
+	PodLogInformation          *PodLogEvent
+	LogLineInformation         *ContainerLogLine
+	PatchInformation           *PatchEvent
 	Error                      error
 }
 
@@ -149,9 +326,129 @@ func (h *eventHandler) sayThatCaseCompletedSuccessfully(testUnit *TestUnit, test
 	}
 }
 
-func (h *eventHandler) sayThatTestingCompletedSuccessfully() {
+func (h *eventHandler) sayThatCaseFailedButFailureIsAllowed(testUnit *TestUnit, testCase *TestCase, err error) {
+	h.eventChannel <- &Event{
+		Type:    TestCaseFailureAllowed,
+		Context: EventContextFor(testUnit, testCase),
+		Error:   err,
+	}
+}
+
+func (h *eventHandler) sayThatUnitCompletedWithFailures(testUnit *TestUnit, numberOfFailures int) {
+	h.eventChannel <- &Event{
+		Type:    TestUnitCompletedWithFailures,
+		Context: EventContextFor(testUnit, nil),
+		OutcomesInformation: &OutcomesEvent{
+			NumberOfFailures: numberOfFailures,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatTestOutcomesAre(matrix *TestOutcomeMatrix) {
+	h.eventChannel <- &Event{
+		Type: TestOutcomes,
+		OutcomesInformation: &OutcomesEvent{
+			Matrix: matrix,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatActionAttemptStarted(actionDescriptor string, attempt uint, maximumNumberOfAttempts uint, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ActionAttemptStarted,
+		Context: EventContextFor(testUnit, testCase),
+		AttemptInformation: &AttemptEvent{
+			ActionDescriptor:        actionDescriptor,
+			Attempt:                 attempt,
+			MaximumNumberOfAttempts: maximumNumberOfAttempts,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatActionAttemptFailed(actionDescriptor string, attempt uint, maximumNumberOfAttempts uint, err error, backoff time.Duration, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ActionAttemptFailed,
+		Context: EventContextFor(testUnit, testCase),
+		AttemptInformation: &AttemptEvent{
+			ActionDescriptor:        actionDescriptor,
+			Attempt:                 attempt,
+			MaximumNumberOfAttempts: maximumNumberOfAttempts,
+			Backoff:                 backoff,
+		},
+		Error: err,
+	}
+}
+
+// sayThatActionTimedOut is sent, before the event for the failure itself, when a Pipeline Action fails because
+// its action timeout or a wait timeout passed.  err wraps ErrorTimeExceeded.
+func (h *eventHandler) sayThatActionTimedOut(actionDescriptor string, err error, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ActionTimedOut,
+		Context: EventContextFor(testUnit, testCase),
+		TimeoutInformation: &TimeoutEvent{
+			ActionDescriptor: actionDescriptor,
+		},
+		Error: err,
+	}
+}
+
+func (h *eventHandler) sayThatIterationStarted(iteration uint, numberOfIterations uint, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    TestCaseIterationStarted,
+		Context: EventContextFor(testUnit, testCase),
+		IterationInformation: &IterationEvent{
+			Iteration:          iteration,
+			NumberOfIterations: numberOfIterations,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatIterationCompletedSuccessfully(iteration uint, numberOfIterations uint, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    TestCaseIterationCompletedSuccessfully,
+		Context: EventContextFor(testUnit, testCase),
+		IterationInformation: &IterationEvent{
+			Iteration:          iteration,
+			NumberOfIterations: numberOfIterations,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatCaseWasAlreadyCompleted(testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    TestCaseAlreadyCompleted,
+		Context: EventContextFor(testUnit, testCase),
+	}
+}
+
+func (h *eventHandler) sayThatTestingCompletedSuccessfully(leftBehindResources []*K8sResourceInformation, retainedAssetsDirectoryPath string) {
 	h.eventChannel <- &Event{
 		Type: TestingCompletedSuccesfully,
+		LeftBehindInformation: &LeftBehindEvent{
+			Resources:           leftBehindResources,
+			AssetsDirectoryPath: retainedAssetsDirectoryPath,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatTestingFailed(leftBehindResources []*K8sResourceInformation, retainedAssetsDirectoryPath string) {
+	h.eventChannel <- &Event{
+		Type: TestingFailed,
+		LeftBehindInformation: &LeftBehindEvent{
+			Resources:           leftBehindResources,
+			AssetsDirectoryPath: retainedAssetsDirectoryPath,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatTestingWasCancelled(err error, leftBehindResources []*K8sResourceInformation, retainedAssetsDirectoryPath string) {
+	h.eventChannel <- &Event{
+		Type:  TestingCancelled,
+		Error: err,
+		LeftBehindInformation: &LeftBehindEvent{
+			Resources:           leftBehindResources,
+			AssetsDirectoryPath: retainedAssetsDirectoryPath,
+		},
 	}
 }
 
@@ -213,7 +510,9 @@ func (h *eventHandler) sayThatResourceTemplateExpansionFailed(templateName strin
 
 func (h *eventHandler) explainAttemptToCreateDefaultNamespace(createdNamespaceApiObject *corev1.Namespace, context EventContext, errorOnCreationAttempt error) {
 	var namespaceName string
-	if createdNamespaceApiObject.Name != "" {
+	if createdNamespaceApiObject == nil {
+		namespaceName = "<not-created>"
+	} else if createdNamespaceApiObject.Name != "" {
 		namespaceName = createdNamespaceApiObject.Name
 	} else {
 		namespaceName = fmt.Sprintf("%s-<generated>", createdNamespaceApiObject.GenerateName)
@@ -272,6 +571,33 @@ func (h *eventHandler) sayThatExecutionSucceeded(actionId string, testUnit *Test
 	}
 }
 
+func (h *eventHandler) sayThatValuesTransformSucceeded(actionId string, stdinBuffer *bytes.Buffer, stdoutBuffer *bytes.Buffer, stderrBuffer *bytes.Buffer, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ValuesTransformSuccess,
+		Context: EventContextFor(testUnit, testCase),
+		ValuesTransformInformation: &ValuesTransformEvent{
+			TransformerName:       actionId,
+			InputValuesRetriever:  stringRetrieverFor(stdinBuffer),
+			OutputValuesRetriever: stringRetrieverFor(stdoutBuffer),
+			StderrOutputRetriever: stringRetrieverFor(stderrBuffer),
+		},
+	}
+}
+
+func (h *eventHandler) sayThatValuesTransformFailed(actionId string, err error, stdinBuffer *bytes.Buffer, stdoutBuffer *bytes.Buffer, stderrBuffer *bytes.Buffer, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ValuesTransformFailure,
+		Context: EventContextFor(testUnit, testCase),
+		ValuesTransformInformation: &ValuesTransformEvent{
+			TransformerName:       actionId,
+			InputValuesRetriever:  stringRetrieverFor(stdinBuffer),
+			OutputValuesRetriever: stringRetrieverFor(stdoutBuffer),
+			StderrOutputRetriever: stringRetrieverFor(stderrBuffer),
+		},
+		Error: err,
+	}
+}
+
 func (h *eventHandler) sayThatPipelineDefinitionIsInvalid(err error) {
 	h.eventChannel <- &Event{
 		Type:    PipelineDefinitionIsInvalid,
@@ -390,3 +716,364 @@ func (handler *eventHandler) sayThatAssetDirectoryDeletionFailed(assetDirectoryP
 	}
 
 }
+
+func (handler *eventHandler) sayThatTestIsResumingFromCheckpoint(checkpointFilePath string) {
+	handler.eventChannel <- &Event{
+		Type: ResumingFromCheckpoint,
+		FileEvent: &FileEvent{
+			Path: checkpointFilePath,
+		},
+	}
+}
+
+func (handler *eventHandler) sayThatCheckpointDoesNotMatchTest(checkpointFilePath string, err error) {
+	handler.eventChannel <- &Event{
+		Type: CheckpointDoesNotMatchTest,
+		FileEvent: &FileEvent{
+			Path: checkpointFilePath,
+		},
+		Error: err,
+	}
+}
+
+func (handler *eventHandler) sayThatCheckpointFileWasCreated(checkpointFilePath string) {
+	handler.eventChannel <- &Event{
+		Type: CheckpointFileCreatedSuccessfully,
+		FileEvent: &FileEvent{
+			Path: checkpointFilePath,
+		},
+	}
+}
+
+func (handler *eventHandler) sayThatCheckpointFileUpdateFailed(checkpointFilePath string, err error) {
+	handler.eventChannel <- &Event{
+		Type: CheckpointFileUpdateFailed,
+		FileEvent: &FileEvent{
+			Path: checkpointFilePath,
+		},
+		Error: err,
+	}
+}
+
+func (handler *eventHandler) sayThatCheckpointFileWasRetained(checkpointFilePath string) {
+	handler.eventChannel <- &Event{
+		Type: CheckpointFileRetained,
+		FileEvent: &FileEvent{
+			Path: checkpointFilePath,
+		},
+	}
+}
+
+func (handler *eventHandler) sayThatCheckpointFileWasRemoved(checkpointFilePath string) {
+	handler.eventChannel <- &Event{
+		Type: CheckpointFileRemovedSuccessfully,
+		FileEvent: &FileEvent{
+			Path: checkpointFilePath,
+		},
+	}
+}
+
+func (handler *eventHandler) sayThatCheckpointFileRemovalFailed(checkpointFilePath string, err error) {
+	handler.eventChannel <- &Event{
+		Type: CheckpointFileRemovalFailed,
+		FileEvent: &FileEvent{
+			Path: checkpointFilePath,
+		},
+		Error: err,
+	}
+}
+
+func (handler *eventHandler) sayThatSkippedActionCouldNotBeNoted(skippedActionsFilePath string, err error, testUnit *TestUnit, testCase *TestCase) {
+	handler.eventChannel <- &Event{
+		Type:    SkippedActionNoteFailed,
+		Context: EventContextFor(testUnit, testCase),
+		FileEvent: &FileEvent{
+			Path: skippedActionsFilePath,
+		},
+		Error: err,
+	}
+}
+
+func (handler *eventHandler) sayThatCopiedFileCouldNotBeRemoved(copiedFilePath string, err error, testUnit *TestUnit, testCase *TestCase) {
+	handler.eventChannel <- &Event{
+		Type:    CopiedFileRemovalFailed,
+		Context: EventContextFor(testUnit, testCase),
+		FileEvent: &FileEvent{
+			Path: copiedFilePath,
+		},
+		Error: err,
+	}
+}
+
+func (handler *eventHandler) sayThatSelectionWasApplied(selection *TestSelection, selectionFilePath string) {
+	handler.eventChannel <- &Event{
+		Type: TestSelectionApplied,
+		SelectionInformation: &SelectionEvent{
+			Description: selection.String(),
+		},
+		FileEvent: &FileEvent{
+			Path: selectionFilePath,
+		},
+	}
+}
+
+func (handler *eventHandler) sayThatSelectionFileCreationFailed(selectionFilePath string, err error) {
+	handler.eventChannel <- &Event{
+		Type: TestSelectionFileCreationFailed,
+		FileEvent: &FileEvent{
+			Path: selectionFilePath,
+		},
+		Error: err,
+	}
+}
+
+func (handler *eventHandler) sayThatResourceWasAcceptedInDryRun(resourceInformation *K8sResourceInformation, testUnit *TestUnit, testCase *TestCase) {
+	handler.eventChannel <- &Event{
+		Type: ResourceDryRunSuccess,
+		ResourceInformation: &ResourceEvent{
+			ResourceDetails: resourceInformation,
+		},
+		Context: EventContextFor(testUnit, testCase),
+	}
+}
+
+func (handler *eventHandler) sayThatActionWasSkippedForDryRun(actionDescriptor string, testUnit *TestUnit, testCase *TestCase) {
+	handler.eventChannel <- &Event{
+		Type: ActionSkippedForDryRun,
+		DryRunInformation: &DryRunEvent{
+			ActionDescriptor: actionDescriptor,
+		},
+		Context: EventContextFor(testUnit, testCase),
+	}
+}
+
+func (handler *eventHandler) sayThatDryRunCompleted(numberOfCasesChecked int, failures []*DryRunFailure, assetsDirectoryPath string) {
+	event := &Event{
+		Type: DryRunCompleted,
+		DryRunInformation: &DryRunEvent{
+			NumberOfCasesChecked: numberOfCasesChecked,
+			Failures:             failures,
+		},
+	}
+
+	if len(failures) > 0 {
+		event.DryRunInformation.RetainedAssetsDirectoryPath = assetsDirectoryPath
+	}
+
+	handler.eventChannel <- event
+}
+
+// sayThatSetupStarted is sent when the setup Pipeline of testUnit, or of the Test if testUnit is nil, starts.  The
+// same holds for the other setup and teardown events.
+func (h *eventHandler) sayThatSetupStarted(testUnit *TestUnit) {
+	h.eventChannel <- &Event{
+		Type:    SetupStarted,
+		Context: EventContextFor(testUnit, nil),
+	}
+}
+
+func (h *eventHandler) sayThatSetupCompletedSuccessfully(testUnit *TestUnit) {
+	h.eventChannel <- &Event{
+		Type:    SetupCompletedSuccessfully,
+		Context: EventContextFor(testUnit, nil),
+	}
+}
+
+func (h *eventHandler) sayThatSetupFailed(testUnit *TestUnit, err error) {
+	h.eventChannel <- &Event{
+		Type:    SetupFailed,
+		Context: EventContextFor(testUnit, nil),
+		Error:   err,
+	}
+}
+
+func (h *eventHandler) sayThatTeardownStarted(testUnit *TestUnit) {
+	h.eventChannel <- &Event{
+		Type:    TeardownStarted,
+		Context: EventContextFor(testUnit, nil),
+	}
+}
+
+func (h *eventHandler) sayThatTeardownCompletedSuccessfully(testUnit *TestUnit) {
+	h.eventChannel <- &Event{
+		Type:    TeardownCompletedSuccessfully,
+		Context: EventContextFor(testUnit, nil),
+	}
+}
+
+func (h *eventHandler) sayThatTeardownFailed(testUnit *TestUnit, err error) {
+	h.eventChannel <- &Event{
+		Type:    TeardownFailed,
+		Context: EventContextFor(testUnit, nil),
+		Error:   err,
+	}
+}
+
+// sayThatActionWasSkipped is sent when a Pipeline Action is not run because its When condition expanded to false.
+func (h *eventHandler) sayThatActionWasSkipped(actionDescriptor string, condition string, expansion string, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ActionSkipped,
+		Context: EventContextFor(testUnit, testCase),
+		ConditionInformation: &ConditionEvent{
+			ActionDescriptor: actionDescriptor,
+			Condition:        condition,
+			Expansion:        expansion,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatActionConditionIsInvalid(actionDescriptor string, condition string, err error, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ActionConditionInvalid,
+		Context: EventContextFor(testUnit, testCase),
+		ConditionInformation: &ConditionEvent{
+			ActionDescriptor: actionDescriptor,
+			Condition:        condition,
+		},
+		Error: err,
+	}
+}
+
+func (h *eventHandler) sayThatResourceWaitSucceeded(actionDescriptor string, description string, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ResourceWaitSucceeded,
+		Context: EventContextFor(testUnit, testCase),
+		WaitInformation: &WaitEvent{
+			ActionDescriptor: actionDescriptor,
+			Description:      description,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatResourceWaitFailed(actionDescriptor string, err error, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ResourceWaitFailed,
+		Context: EventContextFor(testUnit, testCase),
+		WaitInformation: &WaitEvent{
+			ActionDescriptor: actionDescriptor,
+		},
+		Error: err,
+	}
+}
+
+func (h *eventHandler) sayThatFilesWereCopiedFromPod(actionDescriptor string, description string, files []string, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    FilesCopiedFromPodSuccessfully,
+		Context: EventContextFor(testUnit, testCase),
+		CopyInformation: &CopyEvent{
+			ActionDescriptor: actionDescriptor,
+			Description:      description,
+			Files:            files,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatFileCopyFromPodFailed(actionDescriptor string, files []string, err error, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    FileCopyFromPodFailed,
+		Context: EventContextFor(testUnit, testCase),
+		CopyInformation: &CopyEvent{
+			ActionDescriptor: actionDescriptor,
+			Files:            files,
+		},
+		Error: err,
+	}
+}
+
+func (h *eventHandler) sayThatPodLogsWereCaptured(namespaceName string, podName string, containerNames []string, restartedContainerNames []string, directoryPath string, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    PodLogsCaptured,
+		Context: EventContextFor(testUnit, testCase),
+		PodLogInformation: &PodLogEvent{
+			NamespaceName:           namespaceName,
+			PodName:                 podName,
+			ContainerNames:          containerNames,
+			RestartedContainerNames: restartedContainerNames,
+			DirectoryPath:           directoryPath,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatPodLogCaptureFailed(namespaceName string, podName string, containerName string, err error, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    PodLogCaptureFailed,
+		Context: EventContextFor(testUnit, testCase),
+		PodLogInformation: &PodLogEvent{
+			NamespaceName: namespaceName,
+			PodName:       podName,
+			ContainerName: containerName,
+		},
+		Error: err,
+	}
+}
+
+func (h *eventHandler) sayThatContainerLogLineWasReceived(logLine *ContainerLogLine, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:               ContainerLogLineReceived,
+		Context:            EventContextFor(testUnit, testCase),
+		LogLineInformation: logLine,
+	}
+}
+
+func (h *eventHandler) sayThatResourcePatchSucceeded(actionDescriptor string, resourceInformation *K8sResourceInformation, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ResourcePatchSuccess,
+		Context: EventContextFor(testUnit, testCase),
+		PatchInformation: &PatchEvent{
+			ActionDescriptor: actionDescriptor,
+			ResourceDetails:  resourceInformation,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatResourcePatchFailed(actionDescriptor string, err error, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ResourcePatchFailure,
+		Context: EventContextFor(testUnit, testCase),
+		PatchInformation: &PatchEvent{
+			ActionDescriptor: actionDescriptor,
+		},
+		Error: err,
+	}
+}
+
+func (h *eventHandler) sayThatResourceRestorationSucceeded(resourceInformation *K8sResourceInformation, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ResourceRestorationSuccess,
+		Context: EventContextFor(testUnit, testCase),
+		ResourceInformation: &ResourceEvent{
+			ResourceDetails: resourceInformation,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatResourceRestorationFailed(resourceInformation *K8sResourceInformation, err error, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ResourceRestorationFailure,
+		Context: EventContextFor(testUnit, testCase),
+		ResourceInformation: &ResourceEvent{
+			ResourceDetails: resourceInformation,
+		},
+		Error: err,
+	}
+}
+
+func (h *eventHandler) sayThatExistingResourceWasApplied(resourceInformation *K8sResourceInformation, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ExistingResourceApplied,
+		Context: EventContextFor(testUnit, testCase),
+		ResourceInformation: &ResourceEvent{
+			ResourceDetails: resourceInformation,
+		},
+	}
+}
+
+func (h *eventHandler) sayThatResourceBecameReady(resourceInformation *K8sResourceInformation, testUnit *TestUnit, testCase *TestCase) {
+	h.eventChannel <- &Event{
+		Type:    ResourceBecameReady,
+		Context: EventContextFor(testUnit, testCase),
+		ResourceInformation: &ResourceEvent{
+			ResourceDetails: resourceInformation,
+		},
+	}
+}
@@ -30,17 +30,17 @@ func ExampleConfiguration() *Configuration {
 					"PATH":       "/opt/openshift/aspen/client:/usr/bin:/bin",
 					"KUBECONFIG": "/opt/openshift/aspen/client/auth/kubeconfig",
 				},
-				ActionsInOrder: []string{
-					"resources/istio-cni.yaml",
-					"resources/nginx-producer.yaml",
-					"resources/telemetry.yaml",
-					"resources/shared-pvc.yaml",
-					"resources/jmeter-job.yaml",
-					"values-transforms/jmeter-post-job.sh",
-					"resources/jtl-processor-job.yaml",
-					"resources/prom-summary-job.yaml",
-					"resources/extractor.yaml",
-					"executables/extract-test-results.sh",
+				ActionsInOrder: []*ConfigurationPipelineAction{
+					{Action: "resources/istio-cni.yaml"},
+					{Action: "resources/nginx-producer.yaml"},
+					{Action: "resources/telemetry.yaml", When: "{{ .Values.Unit.Sidecar.Use.Telemetry }}"},
+					{Action: "resources/shared-pvc.yaml"},
+					{Action: "resources/jmeter-job.yaml"},
+					{Action: "values-transforms/jmeter-post-job.sh"},
+					{Action: "resources/jtl-processor-job.yaml"},
+					{Action: "resources/prom-summary-job.yaml"},
+					{Action: "resources/extractor.yaml"},
+					{Action: "executables/extract-test-results.sh"},
 				},
 			},
 			Cases: []*TestCase{
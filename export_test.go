@@ -0,0 +1,36 @@
+package jobber
+
+import "context"
+
+// These expose unexported parts of the package to the tests in package jobber_test.
+
+var ContainerNamesOf = containerNamesOf
+var RestartCountOf = restartCountOf
+
+// NewDeletableK8sResourceForTest returns a tracked resource that is not in the Runtime values.
+func NewDeletableK8sResourceForTest(information *K8sResourceInformation, dependencyDepth int, restoresAPatch bool, deletionMethod func(object any) error) *DeletableK8sResource {
+	return &DeletableK8sResource{
+		information:     information,
+		dependencyDepth: dependencyDepth,
+		restoresAPatch:  restoresAPatch,
+		deletionMethod:  deletionMethod,
+	}
+}
+
+// NewDeletableRuntimeResourceForTest returns a tracked resource that is in the Runtime values, and that cannot be
+// deleted.
+func NewDeletableRuntimeResourceForTest(resource *GenericK8sResource) *DeletableK8sResource {
+	return &DeletableK8sResource{
+		information:    resource.Information(),
+		resource:       resource,
+		deletionMethod: func(object any) error { return nil },
+	}
+}
+
+func (runner *Runner) CapturePodLogs(ctx context.Context, resourceTracker *CreatedResourceTracker, paths *TestCaseDirectoryPaths, eventChannel chan<- *Event) {
+	runner.capturePodLogs(ctx, resourceTracker, paths, &eventHandler{eventChannel}, nil, nil)
+}
+
+func (runner *Runner) CaptureFailedAttemptPodLogs(ctx context.Context, action *PipelineAction, attempt uint, resourceTracker *CreatedResourceTracker, paths *TestCaseDirectoryPaths, eventChannel chan<- *Event) {
+	runner.captureFailedAttemptPodLogs(ctx, action, attempt, resourceTracker, paths, &eventHandler{eventChannel}, nil, nil)
+}
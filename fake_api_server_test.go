@@ -0,0 +1,257 @@
+package jobber_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+)
+
+// fakeApiResource is a kind of resource that the fakeApiServer serves.
+type fakeApiResource struct {
+	groupVersion string
+	name         string
+	kind         string
+	namespaced   bool
+}
+
+var fakeApiResources = []fakeApiResource{
+	{groupVersion: "v1", name: "configmaps", kind: "ConfigMap", namespaced: true},
+	{groupVersion: "v1", name: "pods", kind: "Pod", namespaced: true},
+	{groupVersion: "v1", name: "namespaces", kind: "Namespace"},
+	{groupVersion: "batch/v1", name: "jobs", kind: "Job", namespaced: true},
+	{groupVersion: "apiextensions.k8s.io/v1", name: "customresourcedefinitions", kind: "CustomResourceDefinition"},
+}
+
+// fakeApiServer serves discovery for the fakeApiResources, and stores the objects that are created, applied or
+// updated through it, or that a test stores.  Collections can be listed, with equality label selectors.  The log of
+// a container is "log of <container>", or "previous log of <container>" for its previous instance.  Every request
+// for an object, collection or log is recorded.
+type fakeApiServer struct {
+	server               *httptest.Server
+	mutex                sync.Mutex
+	requests             []string
+	objects              map[string][]byte
+	numberOfNamesCreated int
+}
+
+func newFakeApiServer(t *testing.T) (*fakeApiServer, *jobber.Client) {
+	fake := &fakeApiServer{objects: make(map[string][]byte)}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(fake.server.Close)
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: fake
+  cluster:
+    server: %s
+contexts:
+- name: fake
+  context:
+    cluster: fake
+    user: fake
+current-context: fake
+users:
+- name: fake
+  user:
+    token: fake
+`, fake.server.URL)
+
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %s", err)
+	}
+
+	client, err := jobber.NewClientUsingKubeconfigFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+
+	return fake, client
+}
+
+// apiPathFor returns the path of the collection of the resources named resourceName in groupVersion, in the named
+// namespace if it is not empty.
+func apiPathFor(groupVersion string, namespaceName string, resourceName string) string {
+	root := "/apis/" + groupVersion
+	if groupVersion == "v1" {
+		root = "/api/v1"
+	}
+
+	if namespaceName == "" {
+		return root + "/" + resourceName
+	}
+
+	return root + "/namespaces/" + namespaceName + "/" + resourceName
+}
+
+func (fake *fakeApiServer) handle(w http.ResponseWriter, r *http.Request) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	for _, groupVersion := range []string{"v1", "batch/v1", "apiextensions.k8s.io/v1"} {
+		if r.URL.Path == strings.TrimSuffix(apiPathFor(groupVersion, "", ""), "/") {
+			fake.serveDiscoveryFor(w, groupVersion)
+			return
+		}
+	}
+
+	fake.requests = append(fake.requests, r.Method+" "+r.URL.Path)
+
+	if strings.HasSuffix(r.URL.Path, "/log") {
+		w.Header().Set("Content-Type", "text/plain")
+		if r.URL.Query().Get("previous") == "true" {
+			fmt.Fprintf(w, "previous log of %s", r.URL.Query().Get("container"))
+		} else {
+			fmt.Fprintf(w, "log of %s", r.URL.Query().Get("container"))
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	collection, isACollection := fakeApiResourceCollectionNamed(path.Base(r.URL.Path))
+
+	switch {
+	case r.Method == http.MethodGet && isACollection:
+		fake.serveListOf(w, r, collection)
+		return
+	case r.Method == http.MethodGet:
+		if object, exists := fake.objects[r.URL.Path]; exists {
+			w.Write(object)
+			return
+		}
+	case r.Method == http.MethodPost && isACollection:
+		fake.create(w, r)
+		return
+	case r.Method == http.MethodPatch, r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		fake.objects[r.URL.Path] = body
+		w.Write(body)
+		return
+	case r.Method == http.MethodDelete:
+		if _, exists := fake.objects[r.URL.Path]; exists {
+			delete(fake.objects, r.URL.Path)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Success"}`)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+}
+
+func fakeApiResourceCollectionNamed(name string) (fakeApiResource, bool) {
+	for _, resource := range fakeApiResources {
+		if resource.name == name {
+			return resource, true
+		}
+	}
+	return fakeApiResource{}, false
+}
+
+func (fake *fakeApiServer) serveDiscoveryFor(w http.ResponseWriter, groupVersion string) {
+	resources := make([]map[string]any, 0)
+	for _, resource := range fakeApiResources {
+		if resource.groupVersion == groupVersion {
+			resources = append(resources, map[string]any{
+				"name":       resource.name,
+				"namespaced": resource.namespaced,
+				"kind":       resource.kind,
+				"verbs":      []string{"create", "delete", "get", "list", "patch", "update"},
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"kind": "APIResourceList", "apiVersion": "v1", "groupVersion": groupVersion, "resources": resources})
+}
+
+func (fake *fakeApiServer) serveListOf(w http.ResponseWriter, r *http.Request, collection fakeApiResource) {
+	requiredLabels := make(map[string]string)
+	for _, requirement := range strings.Split(r.URL.Query().Get("labelSelector"), ",") {
+		if name, value, isAnEquality := strings.Cut(requirement, "="); isAnEquality {
+			requiredLabels[name] = strings.TrimPrefix(value, "=")
+		}
+	}
+
+	itemPaths := make([]string, 0)
+	for itemPath := range fake.objects {
+		if path.Dir(itemPath) == r.URL.Path {
+			itemPaths = append(itemPaths, itemPath)
+		}
+	}
+	sort.Strings(itemPaths)
+
+	items := make([]map[string]any, 0, len(itemPaths))
+	for _, itemPath := range itemPaths {
+		var item map[string]any
+		json.Unmarshal(fake.objects[itemPath], &item)
+
+		labels, _ := item["metadata"].(map[string]any)["labels"].(map[string]any)
+		matches := true
+		for name, value := range requiredLabels {
+			matches = matches && labels[name] == value
+		}
+
+		if matches {
+			items = append(items, item)
+		}
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{"kind": collection.kind + "List", "apiVersion": collection.groupVersion, "metadata": map[string]any{}, "items": items})
+}
+
+func (fake *fakeApiServer) create(w http.ResponseWriter, r *http.Request) {
+	var object map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&object); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	metadata, _ := object["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	if name == "" {
+		fake.numberOfNamesCreated++
+		name = fmt.Sprintf("%s%05d", metadata["generateName"], fake.numberOfNamesCreated)
+		metadata["name"] = name
+	}
+	metadata["uid"] = "uid-" + name
+
+	itemPath := r.URL.Path + "/" + name
+	if _, exists := fake.objects[itemPath]; exists {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"AlreadyExists","code":409}`)
+		return
+	}
+
+	fake.objects[itemPath], _ = json.Marshal(object)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(fake.objects[itemPath])
+}
+
+// store stores object, as if it had been created, under the collection at collectionPath.
+func (fake *fakeApiServer) store(collectionPath string, object map[string]any) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	fake.objects[collectionPath+"/"+object["metadata"].(map[string]any)["name"].(string)], _ = json.Marshal(object)
+}
+
+func (fake *fakeApiServer) recordedRequests() []string {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return append([]string{}, fake.requests...)
+}
@@ -0,0 +1,233 @@
+package jobber
+
+import (
+	"bufio"
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// followedLogLinesPerSecond is the number of lines of each followed container log that are reported in any one
+	// second.  Lines beyond that are counted, and the count is reported instead.
+	followedLogLinesPerSecond = 10
+
+	// followedLogDrainTimeout is how long, once a followed Job has completed or failed, the logs of its containers
+	// are read before they are abandoned.
+	followedLogDrainTimeout = 5 * time.Second
+
+	// maximumFollowedLogLineLength is the length of the longest line of a container log that can be followed.
+	// Following a log stops at a longer line.
+	maximumFollowedLogLineLength = 64 * 1024
+)
+
+// ContainerLogLine is a line of the log of a container of a Pod of a Job that is being followed.  If LinesSuppressed
+// is greater than zero, the event instead reports that the rate limit caused that many lines to be left out, and
+// Text is empty.
+type ContainerLogLine struct {
+	NamespaceName   string
+	PodName         string
+	ContainerName   string
+	Text            string
+	LinesSuppressed uint
+}
+
+// LogLineRateLimiter limits the rate at which log lines are admitted to a fixed number of lines in each interval.
+// It is not safe for concurrent use.
+type LogLineRateLimiter struct {
+	linesPerInterval        uint
+	interval                time.Duration
+	intervalStart           time.Time
+	linesAdmittedInInterval uint
+	linesSuppressed         uint
+}
+
+// NewLogLineRateLimiter returns a limiter that admits up to linesPerInterval lines in each interval.
+func NewLogLineRateLimiter(linesPerInterval uint, interval time.Duration) *LogLineRateLimiter {
+	return &LogLineRateLimiter{
+		linesPerInterval: linesPerInterval,
+		interval:         interval,
+	}
+}
+
+// Admit returns true if a line that arrives at now should be reported.  In that case, it also returns the number of
+// lines that were not admitted since the last line that was.  An interval starts with the first line that arrives
+// after the previous interval ended.
+func (limiter *LogLineRateLimiter) Admit(now time.Time) (admitted bool, linesSuppressedBefore uint) {
+	if now.Sub(limiter.intervalStart) >= limiter.interval {
+		limiter.intervalStart = now
+		limiter.linesAdmittedInInterval = 0
+	}
+
+	if limiter.linesAdmittedInInterval >= limiter.linesPerInterval {
+		limiter.linesSuppressed++
+		return false, 0
+	}
+
+	limiter.linesAdmittedInInterval++
+
+	return true, limiter.TakeSuppressedLineCount()
+}
+
+// TakeSuppressedLineCount returns the number of lines that were not admitted since the last line that was, and
+// resets that count.
+func (limiter *LogLineRateLimiter) TakeSuppressedLineCount() uint {
+	linesSuppressed := limiter.linesSuppressed
+	limiter.linesSuppressed = 0
+	return linesSuppressed
+}
+
+// jobLogFollower reports, as ContainerLogLineRead events, the lines of the logs of the containers of the Pods
+// owned by a Job, as each container starts.
+type jobLogFollower struct {
+	client        *Client
+	namespaceName string
+	jobName       string
+	eventChannel  chan<- *ActionEvent
+
+	followedContainers map[string]bool
+	streams            sync.WaitGroup
+}
+
+// startFollowingJobLogs starts following the logs of the Pods of the Job resource.  Pods are looked for every
+// probeInterval.  The returned function stops following once the logs that have been opened are drained (or
+// followedLogDrainTimeout passes), and must be called before the action sends its final event.
+func startFollowingJobLogs(ctx context.Context, resource *GenericK8sResource, probeInterval time.Duration, client *Client, eventChannel chan<- *ActionEvent) (stop func()) {
+	follower := &jobLogFollower{
+		client:             client,
+		namespaceName:      resource.NamespaceName(),
+		jobName:            resource.ApiObject().GetName(),
+		eventChannel:       eventChannel,
+		followedContainers: make(map[string]bool),
+	}
+
+	streamCtx, cancelStreams := context.WithCancel(ctx)
+	pollingCtx, cancelPolling := context.WithCancel(streamCtx)
+	pollingDone := make(chan struct{})
+
+	go func() {
+		defer close(pollingDone)
+		follower.pollForContainers(pollingCtx, streamCtx, probeInterval)
+	}()
+
+	return func() {
+		cancelPolling()
+		<-pollingDone
+
+		// A last look, since containers may have started and finished since the last probe
+		follower.followNewlyStartedContainers(streamCtx)
+
+		streamsDrained := make(chan struct{})
+		go func() {
+			follower.streams.Wait()
+			close(streamsDrained)
+		}()
+
+		select {
+		case <-streamsDrained:
+		case <-time.After(followedLogDrainTimeout):
+		}
+
+		cancelStreams()
+		<-streamsDrained
+	}
+}
+
+func (follower *jobLogFollower) pollForContainers(pollingCtx context.Context, streamCtx context.Context, probeInterval time.Duration) {
+	ticker := time.NewTicker(probeInterval)
+	defer ticker.Stop()
+
+	for {
+		follower.followNewlyStartedContainers(streamCtx)
+
+		select {
+		case <-pollingCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// followNewlyStartedContainers starts following each container, of each Pod of the Job, that has started and is
+// not already followed.  Failures to find the Pods or to open a log are ignored, so that they are tried again.
+func (follower *jobLogFollower) followNewlyStartedContainers(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	pods, err := follower.client.PodsOwnedByJob(ctx, follower.namespaceName, follower.jobName)
+	if err != nil {
+		return
+	}
+
+	for _, pod := range pods {
+		for _, containerName := range startedContainerNamesOf(pod) {
+			key := pod.Name + "/" + containerName
+			if follower.followedContainers[key] {
+				continue
+			}
+
+			logStream, err := follower.client.FollowContainerLog(ctx, pod.Namespace, pod.Name, containerName)
+			if err != nil {
+				continue
+			}
+
+			follower.followedContainers[key] = true
+			follower.streams.Add(1)
+
+			go func(podName string, containerName string) {
+				defer follower.streams.Done()
+				defer logStream.Close()
+				follower.reportLines(bufio.NewScanner(logStream), podName, containerName)
+			}(pod.Name, containerName)
+		}
+	}
+}
+
+func (follower *jobLogFollower) reportLines(scanner *bufio.Scanner, podName string, containerName string) {
+	scanner.Buffer(make([]byte, 0, 4096), maximumFollowedLogLineLength)
+	limiter := NewLogLineRateLimiter(followedLogLinesPerSecond, time.Second)
+
+	reportSuppressedLines := func(linesSuppressed uint) {
+		if linesSuppressed > 0 {
+			follower.eventChannel <- &ActionEvent{
+				Type:    ContainerLogLineRead,
+				LogLine: &ContainerLogLine{NamespaceName: follower.namespaceName, PodName: podName, ContainerName: containerName, LinesSuppressed: linesSuppressed},
+			}
+		}
+	}
+
+	for scanner.Scan() {
+		admitted, linesSuppressed := limiter.Admit(time.Now())
+		if !admitted {
+			continue
+		}
+
+		reportSuppressedLines(linesSuppressed)
+
+		follower.eventChannel <- &ActionEvent{
+			Type:    ContainerLogLineRead,
+			LogLine: &ContainerLogLine{NamespaceName: follower.namespaceName, PodName: podName, ContainerName: containerName, Text: scanner.Text()},
+		}
+	}
+
+	reportSuppressedLines(limiter.TakeSuppressedLineCount())
+}
+
+// startedContainerNamesOf returns the names of the containers of pod (including init and ephemeral containers)
+// that are running or have terminated, and so have a log.
+func startedContainerNamesOf(pod *corev1.Pod) []string {
+	names := make([]string, 0)
+
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Running != nil || status.State.Terminated != nil {
+				names = append(names, status.Name)
+			}
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,44 @@
+package jobber_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blorticus-go/jobber"
+)
+
+func TestLogLineRateLimiter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := jobber.NewLogLineRateLimiter(2, time.Second)
+
+	for _, step := range []struct {
+		name                        string
+		offset                      time.Duration
+		expectAdmitted              bool
+		expectLinesSuppressedBefore uint
+	}{
+		{"first line", 0, true, 0},
+		{"second line in interval", 100 * time.Millisecond, true, 0},
+		{"third line in interval", 200 * time.Millisecond, false, 0},
+		{"fourth line in interval", 900 * time.Millisecond, false, 0},
+		{"first line in next interval", 1000 * time.Millisecond, true, 2},
+		{"second line in next interval", 1500 * time.Millisecond, true, 0},
+		{"third line in next interval", 1999 * time.Millisecond, false, 0},
+	} {
+		admitted, linesSuppressedBefore := limiter.Admit(start.Add(step.offset))
+		if admitted != step.expectAdmitted {
+			t.Errorf("[%s] expected admitted = %t, got %t", step.name, step.expectAdmitted, admitted)
+		}
+		if linesSuppressedBefore != step.expectLinesSuppressedBefore {
+			t.Errorf("[%s] expected linesSuppressedBefore = %d, got %d", step.name, step.expectLinesSuppressedBefore, linesSuppressedBefore)
+		}
+	}
+
+	if linesSuppressed := limiter.TakeSuppressedLineCount(); linesSuppressed != 1 {
+		t.Errorf("expected TakeSuppressedLineCount() = 1, got %d", linesSuppressed)
+	}
+
+	if linesSuppressed := limiter.TakeSuppressedLineCount(); linesSuppressed != 0 {
+		t.Errorf("expected second TakeSuppressedLineCount() = 0, got %d", linesSuppressed)
+	}
+}
@@ -0,0 +1,103 @@
+package jobber
+
+import (
+	"sync"
+)
+
+type TestCaseMatrixOutcome string
+
+const (
+	MatrixOutcomeSucceeded      TestCaseMatrixOutcome = "Succeeded"
+	MatrixOutcomeFailed         TestCaseMatrixOutcome = "Failed"
+	MatrixOutcomeFailureAllowed TestCaseMatrixOutcome = "Failed (allowed)"
+	MatrixOutcomeNotRun         TestCaseMatrixOutcome = "Not run"
+)
+
+// TestOutcomeMatrix records the outcome of each Test Case for each Test Unit.  Its methods may be called from
+// concurrently running Test Cases.
+type TestOutcomeMatrix struct {
+	mutex                  sync.RWMutex
+	unitNames              []string
+	caseNames              []string
+	outcomeByUnitAndCase   map[string]map[string]TestCaseMatrixOutcome
+	numberOfFailuresByUnit map[string]int
+}
+
+// newTestOutcomeMatrix returns a matrix for the Units and Cases in config, in which every outcome is
+// MatrixOutcomeNotRun, except those that checkpoint (if it is not nil) records as having succeeded.
+func newTestOutcomeMatrix(config *Configuration, checkpoint *TestCheckpoint) *TestOutcomeMatrix {
+	matrix := &TestOutcomeMatrix{
+		unitNames:              make([]string, 0, len(config.Test.Units)),
+		caseNames:              make([]string, 0, len(config.Test.Cases)),
+		outcomeByUnitAndCase:   make(map[string]map[string]TestCaseMatrixOutcome),
+		numberOfFailuresByUnit: make(map[string]int),
+	}
+
+	for _, testCase := range config.Test.Cases {
+		matrix.caseNames = append(matrix.caseNames, testCase.Name)
+	}
+
+	for _, testUnit := range config.Test.Units {
+		matrix.unitNames = append(matrix.unitNames, testUnit.Name)
+		matrix.outcomeByUnitAndCase[testUnit.Name] = make(map[string]TestCaseMatrixOutcome)
+
+		for _, testCase := range config.Test.Cases {
+			if checkpoint != nil && checkpoint.TestCaseSucceeded(testUnit.Name, testCase.Name) {
+				matrix.outcomeByUnitAndCase[testUnit.Name][testCase.Name] = MatrixOutcomeSucceeded
+			} else {
+				matrix.outcomeByUnitAndCase[testUnit.Name][testCase.Name] = MatrixOutcomeNotRun
+			}
+		}
+	}
+
+	return matrix
+}
+
+func (matrix *TestOutcomeMatrix) record(testUnit *TestUnit, testCase *TestCase, testCaseError error) {
+	matrix.mutex.Lock()
+	defer matrix.mutex.Unlock()
+
+	switch {
+	case testCaseError == nil:
+		matrix.outcomeByUnitAndCase[testUnit.Name][testCase.Name] = MatrixOutcomeSucceeded
+	case testCase.AllowFailure:
+		matrix.outcomeByUnitAndCase[testUnit.Name][testCase.Name] = MatrixOutcomeFailureAllowed
+		matrix.numberOfFailuresByUnit[testUnit.Name]++
+	default:
+		matrix.outcomeByUnitAndCase[testUnit.Name][testCase.Name] = MatrixOutcomeFailed
+		matrix.numberOfFailuresByUnit[testUnit.Name]++
+	}
+}
+
+// UnitNames returns the names of the Test Units, in configuration order.
+func (matrix *TestOutcomeMatrix) UnitNames() []string {
+	return matrix.unitNames
+}
+
+// CaseNames returns the names of the Test Cases, in configuration order.
+func (matrix *TestOutcomeMatrix) CaseNames() []string {
+	return matrix.caseNames
+}
+
+// OutcomeOf returns the outcome of the named Test Case for the named Test Unit.
+func (matrix *TestOutcomeMatrix) OutcomeOf(unitName string, caseName string) TestCaseMatrixOutcome {
+	matrix.mutex.RLock()
+	defer matrix.mutex.RUnlock()
+
+	if outcomeByCase := matrix.outcomeByUnitAndCase[unitName]; outcomeByCase != nil {
+		if outcome, exists := outcomeByCase[caseName]; exists {
+			return outcome
+		}
+	}
+
+	return MatrixOutcomeNotRun
+}
+
+// NumberOfFailuresFor returns the number of Test Cases, including those allowed to fail, that failed for the
+// named Test Unit.
+func (matrix *TestOutcomeMatrix) NumberOfFailuresFor(unitName string) int {
+	matrix.mutex.RLock()
+	defer matrix.mutex.RUnlock()
+
+	return matrix.numberOfFailuresByUnit[unitName]
+}
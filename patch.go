@@ -0,0 +1,257 @@
+package jobber
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// jobberFieldManager is the field manager that jobber uses when it modifies an object.
+const jobberFieldManager = "jobber"
+
+// ResourcePatchDefinition is the target of a patch action, after template expansion.  Patch is applied to the object
+// identified by Target.  Type is Merge (a JSON merge patch, the default), StrategicMerge (a strategic merge patch,
+// which only built-in kinds support) or JSON (a JSON patch).  For Merge and StrategicMerge, Patch must be a mapping;
+// for JSON, it must be a list of operations.
+type ResourcePatchDefinition struct {
+	Target *ResourcePatchTarget `yaml:"Target"`
+	Type   string               `yaml:"Type"`
+	Patch  any                  `yaml:"Patch"`
+
+	patchType  types.PatchType
+	patchBytes []byte
+}
+
+// ResourcePatchTarget identifies the object of a ResourcePatchDefinition.  If Namespace is empty and the kind is
+// namespaced, the default Namespace is used.
+type ResourcePatchTarget struct {
+	ApiVersion string `yaml:"ApiVersion"`
+	Kind       string `yaml:"Kind"`
+	Name       string `yaml:"Name"`
+	Namespace  string `yaml:"Namespace"`
+}
+
+// ParseResourcePatchDefinition decodes and validates a ResourcePatchDefinition from yamlBytes.
+func ParseResourcePatchDefinition(yamlBytes []byte) (*ResourcePatchDefinition, error) {
+	definition := new(ResourcePatchDefinition)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(yamlBytes))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(definition); err != nil {
+		return nil, fmt.Errorf("failed to decode patch definition: %s", err)
+	}
+
+	if definition.Target == nil {
+		return nil, fmt.Errorf("patch definition must have a Target")
+	}
+
+	if definition.Target.ApiVersion == "" || definition.Target.Kind == "" || definition.Target.Name == "" {
+		return nil, fmt.Errorf("patch definition Target must have an ApiVersion, a Kind and a Name")
+	}
+
+	if _, err := schema.ParseGroupVersion(definition.Target.ApiVersion); err != nil {
+		return nil, fmt.Errorf("patch definition Target ApiVersion (%s) is invalid: %s", definition.Target.ApiVersion, err)
+	}
+
+	if definition.Patch == nil {
+		return nil, fmt.Errorf("patch definition must have a Patch")
+	}
+
+	switch definition.Type {
+	case "", "Merge":
+		definition.patchType = types.MergePatchType
+	case "StrategicMerge":
+		definition.patchType = types.StrategicMergePatchType
+	case "JSON":
+		definition.patchType = types.JSONPatchType
+	default:
+		return nil, fmt.Errorf("patch definition Type (%s) must be Merge, StrategicMerge or JSON", definition.Type)
+	}
+
+	_, patchIsAList := definition.Patch.([]any)
+	_, patchIsAMapping := definition.Patch.(map[string]any)
+
+	if definition.patchType == types.JSONPatchType && !patchIsAList {
+		return nil, fmt.Errorf("patch definition Patch must be a list of operations when Type is JSON")
+	}
+
+	if definition.patchType != types.JSONPatchType && !patchIsAMapping {
+		return nil, fmt.Errorf("patch definition Patch must be a mapping unless Type is JSON")
+	}
+
+	patchBytes, err := json.Marshal(definition.Patch)
+	if err != nil {
+		return nil, fmt.Errorf("patch definition Patch cannot be converted to JSON: %s", err)
+	}
+	definition.patchBytes = patchBytes
+
+	return definition, nil
+}
+
+// PatchType returns the type of the patch, as understood by the API server.
+func (definition *ResourcePatchDefinition) PatchType() types.PatchType {
+	return definition.patchType
+}
+
+// PatchBytes returns the patch, as JSON.
+func (definition *ResourcePatchDefinition) PatchBytes() []byte {
+	return definition.patchBytes
+}
+
+// Description describes the object that definition patches (e.g., "v1/ConfigMap istio-system/istio").
+func (definition *ResourcePatchDefinition) Description() string {
+	if definition.Target.Namespace == "" {
+		return fmt.Sprintf("%s/%s %s", definition.Target.ApiVersion, definition.Target.Kind, definition.Target.Name)
+	}
+
+	return fmt.Sprintf("%s/%s %s/%s", definition.Target.ApiVersion, definition.Target.Kind, definition.Target.Namespace, definition.Target.Name)
+}
+
+// PatchedK8sResource is an object that a patch action modified (or that a resources action in Apply mode found
+// already existing and updated), along with the object as it was before, so that it can be restored.  For an
+// applied object, appliedConfiguration is what was applied.
+type PatchedK8sResource struct {
+	information          *K8sResourceInformation
+	groupVersionResource schema.GroupVersionResource
+	original             *unstructured.Unstructured
+	appliedConfiguration *unstructured.Unstructured
+	client               *Client
+}
+
+// Information describes the patched object.
+func (patched *PatchedK8sResource) Information() *K8sResourceInformation {
+	return patched.information
+}
+
+// Restore replaces the patched object with the object as it was before the patch was applied.  Changes that others
+// have made since then are also undone.  If the object has been changed again between its retrieval and its
+// replacement, the restoration is tried again.  An applied object is instead reverted field by field (see
+// RevertConfigurationFor), so that changes that others have made are kept.
+func (patched *PatchedK8sResource) Restore(ctx context.Context) error {
+	resourceInterface := patched.client.Dynamic().Resource(patched.groupVersionResource).Namespace(patched.original.GetNamespace())
+
+	if patched.appliedConfiguration != nil {
+		revertConfiguration, err := RevertConfigurationFor(patched.appliedConfiguration, patched.original).MarshalJSON()
+		if err != nil {
+			return err
+		}
+
+		forceOwnership := true
+		_, err = resourceInterface.Patch(ctx, patched.original.GetName(), types.ApplyPatchType, revertConfiguration, metav1.PatchOptions{FieldManager: jobberFieldManager, Force: &forceOwnership})
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := resourceInterface.Get(ctx, patched.original.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		_, err = resourceInterface.Update(ctx, RestorationOf(patched.original, current), metav1.UpdateOptions{FieldManager: jobberFieldManager})
+		return err
+	})
+}
+
+// RestorationOf returns the object that, submitted as an update to current, restores original.  It is original,
+// with the resourceVersion of current, and without managedFields (which the API server computes).
+func RestorationOf(original *unstructured.Unstructured, current *unstructured.Unstructured) *unstructured.Unstructured {
+	restoration := original.DeepCopy()
+	restoration.SetResourceVersion(current.GetResourceVersion())
+	restoration.SetManagedFields(nil)
+
+	return restoration
+}
+
+// applyPatch retrieves the object that definition identifies, then applies the patch to it.  The object as it was
+// before the patch is returned, so that it can be restored.
+func (definition *ResourcePatchDefinition) applyPatch(ctx context.Context, defaultNamespaceName string, client *Client) (*PatchedK8sResource, error) {
+	gv, _ := schema.ParseGroupVersion(definition.Target.ApiVersion)
+
+	gvr, isNamespaced, err := client.DetermineResourceAndScopeFromGroupVersionKind(gv.WithKind(definition.Target.Kind))
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceName := ""
+	if isNamespaced {
+		if namespaceName = definition.Target.Namespace; namespaceName == "" {
+			namespaceName = defaultNamespaceName
+		}
+	}
+
+	resourceInterface := client.Dynamic().Resource(gvr).Namespace(namespaceName)
+
+	original, err := resourceInterface.Get(ctx, definition.Target.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve object to patch: %w", err)
+	}
+
+	if _, err := resourceInterface.Patch(ctx, definition.Target.Name, definition.patchType, definition.patchBytes, metav1.PatchOptions{FieldManager: jobberFieldManager}); err != nil {
+		return nil, fmt.Errorf("failed to patch object: %w", err)
+	}
+
+	return &PatchedK8sResource{
+		information: &K8sResourceInformation{
+			Kind:          definition.Target.Kind,
+			Name:          definition.Target.Name,
+			NamespaceName: namespaceName,
+		},
+		groupVersionResource: gvr,
+		original:             original,
+		client:               client,
+	}, nil
+}
+
+// runPatch expands the patch definition template, then patches the object that it identifies.  The patched object is
+// delivered in a ResourcePatched event, so that it can be restored.
+func (action *PipelineAction) runPatch(ctx context.Context, pipelineVariables *PipelineVariables, client *Client, eventChannel chan<- *ActionEvent) {
+	templateBuffer, err := action.expandDefinitionTemplate(pipelineVariables)
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: err,
+		}
+		return
+	}
+
+	eventChannel <- &ActionEvent{
+		Type:                   TemplateExpanded,
+		ExpandedTemplateBuffer: templateBuffer,
+	}
+
+	definition, err := ParseResourcePatchDefinition(templateBuffer.Bytes())
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: fmt.Errorf("in (%s): %s", action.ActionFullyQualifiedPath, err),
+		}
+		return
+	}
+
+	patchedResource, err := definition.applyPatch(ctx, pipelineVariables.Runtime.DefaultNamespace.Name, client)
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: fmt.Errorf("failed to patch (%s): %w", definition.Description(), explainedIfActionTimedOut(ctx, err)),
+		}
+		return
+	}
+
+	eventChannel <- &ActionEvent{
+		Type:            ResourcePatched,
+		PatchedResource: patchedResource,
+	}
+
+	eventChannel <- &ActionEvent{
+		Type: ActionCompletedSuccessfully,
+	}
+}
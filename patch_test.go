@@ -0,0 +1,175 @@
+package jobber_test
+
+import (
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResourcePatchDefinitions(t *testing.T) {
+	for _, testCase := range []struct {
+		testName           string
+		definition         string
+		expectAParseError  bool
+		expectedPatchType  types.PatchType
+		expectedPatchBytes string
+	}{
+		{
+			testName: "merge patch by default",
+			definition: `
+Target: {ApiVersion: v1, Kind: ConfigMap, Name: istio, Namespace: istio-system}
+Patch:
+  data:
+    mesh: "accessLogFile: /dev/stdout"
+`,
+			expectedPatchType:  types.MergePatchType,
+			expectedPatchBytes: `{"data":{"mesh":"accessLogFile: /dev/stdout"}}`,
+		},
+		{
+			testName: "strategic merge patch",
+			definition: `
+Target: {ApiVersion: apps/v1, Kind: Deployment, Name: nginx}
+Type: StrategicMerge
+Patch:
+  spec:
+    template:
+      spec:
+        containers:
+          - name: nginx
+            env: [{name: WORKERS, value: "4"}]
+`,
+			expectedPatchType:  types.StrategicMergePatchType,
+			expectedPatchBytes: `{"spec":{"template":{"spec":{"containers":[{"env":[{"name":"WORKERS","value":"4"}],"name":"nginx"}]}}}}`,
+		},
+		{
+			testName: "JSON patch",
+			definition: `
+Target: {ApiVersion: apps/v1, Kind: Deployment, Name: nginx}
+Type: JSON
+Patch:
+  - {op: replace, path: /spec/replicas, value: 3}
+`,
+			expectedPatchType:  types.JSONPatchType,
+			expectedPatchBytes: `[{"op":"replace","path":"/spec/replicas","value":3}]`,
+		},
+		{
+			testName: "JSON patch that is a mapping",
+			definition: `
+Target: {ApiVersion: apps/v1, Kind: Deployment, Name: nginx}
+Type: JSON
+Patch: {spec: {replicas: 3}}
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "merge patch that is a list",
+			definition: `
+Target: {ApiVersion: apps/v1, Kind: Deployment, Name: nginx}
+Patch:
+  - {op: replace, path: /spec/replicas, value: 3}
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "unknown Type",
+			definition: `
+Target: {ApiVersion: apps/v1, Kind: Deployment, Name: nginx}
+Type: Apply
+Patch: {spec: {replicas: 3}}
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "Target without Name",
+			definition: `
+Target: {ApiVersion: apps/v1, Kind: Deployment}
+Patch: {spec: {replicas: 3}}
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "no Patch",
+			definition: `
+Target: {ApiVersion: apps/v1, Kind: Deployment, Name: nginx}
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "unknown field",
+			definition: `
+Target: {ApiVersion: apps/v1, Kind: Deployment, Name: nginx}
+Pach: {spec: {replicas: 3}}
+`,
+			expectAParseError: true,
+		},
+	} {
+		definition, err := jobber.ParseResourcePatchDefinition([]byte(testCase.definition))
+
+		if testCase.expectAParseError {
+			if err == nil {
+				t.Errorf("[%s] expected a parse error, got no error", testCase.testName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no parse error, got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		if definition.PatchType() != testCase.expectedPatchType {
+			t.Errorf("[%s] expected PatchType() = (%s), got (%s)", testCase.testName, testCase.expectedPatchType, definition.PatchType())
+		}
+
+		if string(definition.PatchBytes()) != testCase.expectedPatchBytes {
+			t.Errorf("[%s] expected PatchBytes() = (%s), got (%s)", testCase.testName, testCase.expectedPatchBytes, definition.PatchBytes())
+		}
+	}
+}
+
+func TestRestorationOf(t *testing.T) {
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":            "istio",
+			"namespace":       "istio-system",
+			"resourceVersion": "100",
+			"managedFields":   []any{map[string]any{"manager": "istiod"}},
+		},
+		"data": map[string]any{"mesh": "accessLogFile: \"\""},
+	}}
+
+	current := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":            "istio",
+			"namespace":       "istio-system",
+			"resourceVersion": "105",
+		},
+		"data": map[string]any{"mesh": "accessLogFile: /dev/stdout"},
+	}}
+
+	expected := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":            "istio",
+			"namespace":       "istio-system",
+			"resourceVersion": "105",
+		},
+		"data": map[string]any{"mesh": "accessLogFile: \"\""},
+	}}
+
+	if diff := deep.Equal(jobber.RestorationOf(original, current), expected); diff != nil {
+		t.Errorf("RestorationOf() differs from expected: %s", diff)
+	}
+
+	if original.GetResourceVersion() != "100" {
+		t.Errorf("expected original to be unchanged, but its resourceVersion is (%s)", original.GetResourceVersion())
+	}
+}
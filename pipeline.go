@@ -1,8 +1,66 @@
 package jobber
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Pipeline struct {
 	actions           []*PipelineAction
 	indexOfNextAction int
+
+	// dependencies holds, for each action, the indexes of the actions on which it depends.  It is nil if the
+	// Pipeline is not a dependency graph, in which case each action simply follows the one before it.
+	dependencies [][]int
+}
+
+// newPipeline returns a Pipeline for actions, with the given dependencies (which may be nil), and sets the
+// dependency depth of each action.  It returns an error if there is a dependency cycle.
+func newPipeline(actions []*PipelineAction, dependencies [][]int) (*Pipeline, error) {
+	pipeline := &Pipeline{
+		actions:           actions,
+		indexOfNextAction: 0,
+		dependencies:      dependencies,
+	}
+
+	for actionIndex := range actions {
+		depth, err := pipeline.dependencyDepthOf(actionIndex, make(map[int]bool))
+		if err != nil {
+			return nil, err
+		}
+		actions[actionIndex].setDependencyDepth(depth)
+	}
+
+	return pipeline, nil
+}
+
+// dependencyDepthOf returns the length of the longest chain of dependencies leading to the action at actionIndex.
+// If the Pipeline is not a dependency graph, that is the index itself.  actionsInChain holds the indexes of the
+// actions that depend, directly or indirectly, on this one.
+func (pipeline *Pipeline) dependencyDepthOf(actionIndex int, actionsInChain map[int]bool) (int, error) {
+	if pipeline.dependencies == nil {
+		return actionIndex, nil
+	}
+
+	if actionsInChain[actionIndex] {
+		return 0, fmt.Errorf("action (%s) depends on itself through its dependencies", pipeline.actions[actionIndex].Descriptor)
+	}
+
+	actionsInChain[actionIndex] = true
+	defer delete(actionsInChain, actionIndex)
+
+	depth := 0
+	for _, dependencyIndex := range pipeline.dependencies[actionIndex] {
+		dependencyDepth, err := pipeline.dependencyDepthOf(dependencyIndex, actionsInChain)
+		if err != nil {
+			return 0, err
+		}
+		if dependencyDepth+1 > depth {
+			depth = dependencyDepth + 1
+		}
+	}
+
+	return depth, nil
 }
 
 func NewPipelineFromStringDescriptors(pipelineDescriptors []string, pipelineActionBasePath string) (*Pipeline, error) {
@@ -16,10 +74,126 @@ func NewPipelineFromStringDescriptors(pipelineDescriptors []string, pipelineActi
 		actions[descriptorIndex] = action
 	}
 
+	return newPipeline(actions, nil)
+}
+
+// NewPipelineFromConfiguration returns a Pipeline for the entries of .Test.Pipeline.ActionsInOrder in
+// testConfiguration, in which each action carries the name, environment, arguments, retry policy, timeouts and
+// condition of its entry.
+func NewPipelineFromConfiguration(testConfiguration *ConfigurationTest) (*Pipeline, error) {
+	return NewPipelineFromConfigurationEntries(testConfiguration.Pipeline.ActionsInOrder, testConfiguration)
+}
+
+// NewPipelineFromConfigurationEntries is the same as NewPipelineFromConfiguration(), but the Pipeline is for
+// pipelineEntries, which may be any of the Pipeline Action lists in testConfiguration (e.g., .Test.Pipeline.UnitSetup).
+// A Parallel entry becomes a single action of type ParallelGroup.  If any entry sets DependsOn, the Pipeline is a
+// dependency graph.
+func NewPipelineFromConfigurationEntries(pipelineEntries []*ConfigurationPipelineAction, testConfiguration *ConfigurationTest) (*Pipeline, error) {
+	actions := make([]*PipelineAction, len(pipelineEntries))
+	indexByName := make(map[string]int)
+	isADependencyGraph := false
+
+	for entryIndex, entry := range pipelineEntries {
+		action, err := newPipelineActionFromConfigurationEntry(entry, testConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		actions[entryIndex] = action
+
+		if entry.Name != "" {
+			indexByName[entry.Name] = entryIndex
+		}
+		if len(entry.DependsOn) > 0 {
+			isADependencyGraph = true
+		}
+	}
+
+	if !isADependencyGraph {
+		return newPipeline(actions, nil)
+	}
+
+	dependencies := make([][]int, len(pipelineEntries))
+	for entryIndex, entry := range pipelineEntries {
+		dependencies[entryIndex] = make([]int, 0, len(entry.DependsOn))
+		for _, dependencyName := range entry.DependsOn {
+			dependencyIndex, isAnEntry := indexByName[dependencyName]
+			if !isAnEntry {
+				return nil, fmt.Errorf("action (%s) depends on (%s), which is not the name of an action", entry.Action, dependencyName)
+			}
+			dependencies[entryIndex] = append(dependencies[entryIndex], dependencyIndex)
+		}
+	}
+
+	return newPipeline(actions, dependencies)
+}
+
+func newPipelineActionFromConfigurationEntry(entry *ConfigurationPipelineAction, testConfiguration *ConfigurationTest) (*PipelineAction, error) {
+	if entry.Parallel != nil {
+		group := &PipelineAction{
+			Type:      ParallelGroup,
+			Name:      entry.Name,
+			DependsOn: entry.DependsOn,
+			Members:   make([]*PipelineAction, len(entry.Parallel)),
+		}
+
+		memberDescriptors := make([]string, len(entry.Parallel))
+		for memberIndex, memberEntry := range entry.Parallel {
+			member, err := newPipelineActionFromConfigurationEntry(memberEntry, testConfiguration)
+			if err != nil {
+				return nil, err
+			}
+			group.Members[memberIndex] = member
+			memberDescriptors[memberIndex] = member.Descriptor
+		}
+
+		group.Descriptor = fmt.Sprintf("Parallel(%s)", strings.Join(memberDescriptors, ", "))
+
+		return group, nil
+	}
+
+	action, err := PipelineActionFromStringDescriptor(entry.Action, testConfiguration.Pipeline.ActionDefinitionsRootDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	action.Name = entry.Name
+	action.DependsOn = entry.DependsOn
+	action.Env = entry.Env
+	action.Args = entry.Args
+	action.Follow = entry.Follow
+	if entry.Mode != "" {
+		if action.Mode, err = ResourceModeFromString(entry.Mode); err != nil {
+			return nil, err
+		}
+	}
+	action.RetryPolicy = NewActionRetryPolicyFromConfiguration(entry)
+	action.Timeouts = NewActionTimeoutsFromConfiguration(entry, testConfiguration.Timeouts)
+	if action.Condition, err = NewActionConditionFromConfiguration(entry); err != nil {
+		return nil, err
+	}
+
+	return action, nil
+}
+
+// Copy returns a Pipeline with the same actions as pipeline, but which is iterated independently of it.  This allows
+// the Pipeline to be run for more than one Test Case at a time.
+func (pipeline *Pipeline) Copy() *Pipeline {
 	return &Pipeline{
-		actions:           actions,
+		actions:           pipeline.actions,
 		indexOfNextAction: 0,
-	}, nil
+		dependencies:      pipeline.dependencies,
+	}
+}
+
+// IsADependencyGraph returns true if the actions of pipeline run according to their dependencies rather than in
+// order.
+func (pipeline *Pipeline) IsADependencyGraph() bool {
+	return pipeline.dependencies != nil
+}
+
+// NumberOfActions returns the number of actions in pipeline.
+func (pipeline *Pipeline) NumberOfActions() int {
+	return len(pipeline.actions)
 }
 
 func (pipeline *Pipeline) NextAction() *PipelineAction {
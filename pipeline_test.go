@@ -3,6 +3,7 @@ package jobber_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/blorticus-go/jobber"
 	"github.com/go-test/deep"
@@ -46,27 +47,27 @@ func TestPipelineDescriptors(t *testing.T) {
 	for testCaseIndex, testCase := range []*pipelineDescriptorTestCase{
 		{
 			descriptorString:       "resources/first",
-			expectedPipelineAction: &jobber.PipelineAction{jobber.TemplatedResource, "resources/first", "/opt/templates/resources/first"},
+			expectedPipelineAction: &jobber.PipelineAction{Type: jobber.TemplatedResource, Descriptor: "resources/first", ActionFullyQualifiedPath: "/opt/templates/resources/first"},
 		},
 		{
 			descriptorString:       "values-transforms/post-asm.sh",
-			expectedPipelineAction: &jobber.PipelineAction{jobber.ValuesTransform, "values-transforms/post-asm.sh", "/opt/templates/values-transforms/post-asm.sh"},
+			expectedPipelineAction: &jobber.PipelineAction{Type: jobber.ValuesTransform, Descriptor: "values-transforms/post-asm.sh", ActionFullyQualifiedPath: "/opt/templates/values-transforms/post-asm.sh"},
 		},
 		{
 			descriptorString:       "executables/extract-data.sh",
-			expectedPipelineAction: &jobber.PipelineAction{jobber.Executable, "executables/extract-data.sh", "/opt/templates/executables/extract-data.sh"},
+			expectedPipelineAction: &jobber.PipelineAction{Type: jobber.Executable, Descriptor: "executables/extract-data.sh", ActionFullyQualifiedPath: "/opt/templates/executables/extract-data.sh"},
 		},
 		{
 			descriptorString:       "resources/jobs/first",
-			expectedPipelineAction: &jobber.PipelineAction{jobber.TemplatedResource, "resources/jobs/first", "/opt/templates/resources/jobs/first"},
+			expectedPipelineAction: &jobber.PipelineAction{Type: jobber.TemplatedResource, Descriptor: "resources/jobs/first", ActionFullyQualifiedPath: "/opt/templates/resources/jobs/first"},
 		},
 		{
 			descriptorString:       "values-transforms/asm/post-asm.sh",
-			expectedPipelineAction: &jobber.PipelineAction{jobber.ValuesTransform, "values-transforms/asm/post-asm.sh", "/opt/templates/values-transforms/asm/post-asm.sh"},
+			expectedPipelineAction: &jobber.PipelineAction{Type: jobber.ValuesTransform, Descriptor: "values-transforms/asm/post-asm.sh", ActionFullyQualifiedPath: "/opt/templates/values-transforms/asm/post-asm.sh"},
 		},
 		{
 			descriptorString:       "executables/extractor/extract-data.sh",
-			expectedPipelineAction: &jobber.PipelineAction{jobber.Executable, "executables/extractor/extract-data.sh", "/opt/templates/executables/extractor/extract-data.sh"},
+			expectedPipelineAction: &jobber.PipelineAction{Type: jobber.Executable, Descriptor: "executables/extractor/extract-data.sh", ActionFullyQualifiedPath: "/opt/templates/executables/extractor/extract-data.sh"},
 		},
 		{
 			descriptorString: "",
@@ -136,15 +137,15 @@ func TestPipeline(t *testing.T) {
 		}
 
 		if diff := deep.Equal(actions, []*jobber.PipelineAction{
-			{jobber.TemplatedResource, "resources/nginx-producer.yaml", "/opt/templates/resources/nginx-producer.yaml"},
-			{jobber.TemplatedResource, "resources/telemetry.yaml", "/opt/templates/resources/telemetry.yaml"},
-			{jobber.ValuesTransform, "values-transforms/post-asm.sh", "/opt/templates/values-transforms/post-asm.sh"},
-			{jobber.TemplatedResource, "resources/shared-pvc.yaml", "/opt/templates/resources/shared-pvc.yaml"},
-			{jobber.TemplatedResource, "resources/jmeter-job.yaml", "/opt/templates/resources/jmeter-job.yaml"},
-			{jobber.TemplatedResource, "resources/jtl-processor-job.yaml", "/opt/templates/resources/jtl-processor-job.yaml"},
-			{jobber.TemplatedResource, "resources/container-resources-job.yaml", "/opt/templates/resources/container-resources-job.yaml"},
-			{jobber.TemplatedResource, "resources/retrieval-pod.yaml", "/opt/templates/resources/retrieval-pod.yaml"},
-			{jobber.Executable, "executables/extract-data.sh", "/opt/templates/executables/extract-data.sh"},
+			{Type: jobber.TemplatedResource, Descriptor: "resources/nginx-producer.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/nginx-producer.yaml"},
+			{Type: jobber.TemplatedResource, Descriptor: "resources/telemetry.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/telemetry.yaml"},
+			{Type: jobber.ValuesTransform, Descriptor: "values-transforms/post-asm.sh", ActionFullyQualifiedPath: "/opt/templates/values-transforms/post-asm.sh"},
+			{Type: jobber.TemplatedResource, Descriptor: "resources/shared-pvc.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/shared-pvc.yaml"},
+			{Type: jobber.TemplatedResource, Descriptor: "resources/jmeter-job.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/jmeter-job.yaml"},
+			{Type: jobber.TemplatedResource, Descriptor: "resources/jtl-processor-job.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/jtl-processor-job.yaml"},
+			{Type: jobber.TemplatedResource, Descriptor: "resources/container-resources-job.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/container-resources-job.yaml"},
+			{Type: jobber.TemplatedResource, Descriptor: "resources/retrieval-pod.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/retrieval-pod.yaml"},
+			{Type: jobber.Executable, Descriptor: "executables/extract-data.sh", ActionFullyQualifiedPath: "/opt/templates/executables/extract-data.sh"},
 		}); diff != nil {
 			t.Error(diff)
 		}
@@ -194,17 +195,193 @@ func TestTrailingSlashOnBasePath(t *testing.T) {
 	}
 
 	if diff := deep.Equal(actions, []*jobber.PipelineAction{
-		{jobber.TemplatedResource, "resources/nginx-producer.yaml", "/opt/templates/resources/nginx-producer.yaml"},
-		{jobber.TemplatedResource, "resources/telemetry.yaml", "/opt/templates/resources/telemetry.yaml"},
-		{jobber.ValuesTransform, "values-transforms/post-asm.sh", "/opt/templates/values-transforms/post-asm.sh"},
-		{jobber.TemplatedResource, "resources/shared-pvc.yaml", "/opt/templates/resources/shared-pvc.yaml"},
-		{jobber.TemplatedResource, "resources/jmeter-job.yaml", "/opt/templates/resources/jmeter-job.yaml"},
-		{jobber.TemplatedResource, "resources/jtl-processor-job.yaml", "/opt/templates/resources/jtl-processor-job.yaml"},
-		{jobber.TemplatedResource, "resources/container-resources-job.yaml", "/opt/templates/resources/container-resources-job.yaml"},
-		{jobber.TemplatedResource, "resources/retrieval-pod.yaml", "/opt/templates/resources/retrieval-pod.yaml"},
-		{jobber.Executable, "executables/extract-data.sh", "/opt/templates/executables/extract-data.sh"},
+		{Type: jobber.TemplatedResource, Descriptor: "resources/nginx-producer.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/nginx-producer.yaml"},
+		{Type: jobber.TemplatedResource, Descriptor: "resources/telemetry.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/telemetry.yaml"},
+		{Type: jobber.ValuesTransform, Descriptor: "values-transforms/post-asm.sh", ActionFullyQualifiedPath: "/opt/templates/values-transforms/post-asm.sh"},
+		{Type: jobber.TemplatedResource, Descriptor: "resources/shared-pvc.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/shared-pvc.yaml"},
+		{Type: jobber.TemplatedResource, Descriptor: "resources/jmeter-job.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/jmeter-job.yaml"},
+		{Type: jobber.TemplatedResource, Descriptor: "resources/jtl-processor-job.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/jtl-processor-job.yaml"},
+		{Type: jobber.TemplatedResource, Descriptor: "resources/container-resources-job.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/container-resources-job.yaml"},
+		{Type: jobber.TemplatedResource, Descriptor: "resources/retrieval-pod.yaml", ActionFullyQualifiedPath: "/opt/templates/resources/retrieval-pod.yaml"},
+		{Type: jobber.Executable, Descriptor: "executables/extract-data.sh", ActionFullyQualifiedPath: "/opt/templates/executables/extract-data.sh"},
 	}); diff != nil {
 		t.Error(diff)
 	}
 
 }
+
+func TestPipelineCopyIsIteratedIndependently(t *testing.T) {
+	pipeline, err := jobber.NewPipelineFromStringDescriptors([]string{
+		"resources/nginx-producer.yaml",
+		"resources/jmeter-job.yaml",
+		"executables/extract-data.sh",
+	}, "/opt/templates")
+
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	first := pipeline.Restart()
+	pipelineCopy := pipeline.Copy()
+
+	if second := pipeline.NextAction(); second == nil || second.Descriptor != "resources/jmeter-job.yaml" {
+		t.Fatalf("expected second action of original pipeline to be (resources/jmeter-job.yaml)")
+	}
+
+	if copyFirst := pipelineCopy.NextAction(); copyFirst != first {
+		t.Errorf("expected first action of pipeline copy to be the first action of the original pipeline")
+	}
+}
+
+func TestPipelineFromConfigurationResolvesTimeouts(t *testing.T) {
+	pipeline, err := jobber.NewPipelineFromConfiguration(&jobber.ConfigurationTest{
+		Pipeline: &jobber.ConfigurationPipeline{
+			ActionDefinitionsRootDirectory: "/opt/templates",
+			ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+				{Action: "resources/nginx-producer.yaml"},
+				{Action: "resources/jmeter-job.yaml", Timeout: time.Hour, WaitTimeout: 45 * time.Minute},
+			},
+		},
+		Timeouts: &jobber.ConfigurationTimeouts{
+			Action:        10 * time.Minute,
+			ProbeInterval: 5 * time.Second,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	for _, expectedTimeouts := range []*jobber.ActionTimeouts{
+		{Action: 10 * time.Minute, ProbeInterval: 5 * time.Second},
+		{Action: time.Hour, Wait: 45 * time.Minute, ProbeInterval: 5 * time.Second},
+	} {
+		action := pipeline.NextAction()
+		if diff := deep.Equal(action.Timeouts, expectedTimeouts); diff != nil {
+			t.Errorf("[%s] %v", action.Descriptor, diff)
+		}
+	}
+}
+
+func TestPipelineFromConfigurationWithParallelGroup(t *testing.T) {
+	pipeline, err := jobber.NewPipelineFromConfiguration(&jobber.ConfigurationTest{
+		Pipeline: &jobber.ConfigurationPipeline{
+			ActionDefinitionsRootDirectory: "/opt/templates",
+			ActionsInOrder: []*jobber.ConfigurationPipelineAction{
+				{
+					Name: "servers",
+					Parallel: []*jobber.ConfigurationPipelineAction{
+						{Action: "resources/nginx-producer.yaml"},
+						{Action: "resources/jmeter-job.yaml", Retries: 2, Backoff: time.Second},
+					},
+				},
+				{Action: "executables/extract-test-results.sh"},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	group := pipeline.NextAction()
+	if group.Type != jobber.ParallelGroup {
+		t.Fatalf("expected first action to be a ParallelGroup")
+	}
+
+	if group.Descriptor != "Parallel(resources/nginx-producer.yaml, resources/jmeter-job.yaml)" {
+		t.Errorf("expected Descriptor of group to be (Parallel(resources/nginx-producer.yaml, resources/jmeter-job.yaml)), got (%s)", group.Descriptor)
+	}
+
+	if len(group.Members) != 2 {
+		t.Fatalf("expected group to have 2 members, got %d", len(group.Members))
+	}
+
+	if group.Members[1].Type != jobber.TemplatedResource || group.Members[1].RetryPolicy == nil || group.Members[1].RetryPolicy.Retries != 2 {
+		t.Errorf("expected second member to be a TemplatedResource with 2 retries")
+	}
+
+	if action := pipeline.NextAction(); action == nil || action.Type != jobber.Executable {
+		t.Errorf("expected second action to be an Executable")
+	}
+}
+
+func TestPipelineFromConfigurationAsDependencyGraph(t *testing.T) {
+	for _, testCase := range []struct {
+		testName                    string
+		entries                     []*jobber.ConfigurationPipelineAction
+		expectAnError               bool
+		expectADependencyGraph      bool
+		expectedDependsOnOfLastItem []string
+	}{
+		{
+			testName: "no DependsOn",
+			entries: []*jobber.ConfigurationPipelineAction{
+				{Action: "resources/nginx-producer.yaml", Name: "producer"},
+				{Action: "resources/jmeter-job.yaml"},
+			},
+			expectADependencyGraph: false,
+		},
+		{
+			testName: "diamond",
+			entries: []*jobber.ConfigurationPipelineAction{
+				{Action: "resources/shared-pvc.yaml", Name: "pvc"},
+				{Action: "resources/nginx-producer.yaml", Name: "producer", DependsOn: []string{"pvc"}},
+				{Action: "resources/jmeter-job.yaml", Name: "jmeter", DependsOn: []string{"pvc"}},
+				{Action: "executables/extract-test-results.sh", DependsOn: []string{"producer", "jmeter"}},
+			},
+			expectADependencyGraph:      true,
+			expectedDependsOnOfLastItem: []string{"producer", "jmeter"},
+		},
+		{
+			testName: "unknown dependency",
+			entries: []*jobber.ConfigurationPipelineAction{
+				{Action: "resources/nginx-producer.yaml", Name: "producer"},
+				{Action: "resources/jmeter-job.yaml", DependsOn: []string{"consumer"}},
+			},
+			expectAnError: true,
+		},
+		{
+			testName: "cycle",
+			entries: []*jobber.ConfigurationPipelineAction{
+				{Action: "resources/nginx-producer.yaml", Name: "producer", DependsOn: []string{"jmeter"}},
+				{Action: "resources/jmeter-job.yaml", Name: "jmeter", DependsOn: []string{"producer"}},
+			},
+			expectAnError: true,
+		},
+	} {
+		pipeline, err := jobber.NewPipelineFromConfigurationEntries(testCase.entries, &jobber.ConfigurationTest{
+			Pipeline: &jobber.ConfigurationPipeline{ActionDefinitionsRootDirectory: "/opt/templates"},
+		})
+
+		if testCase.expectAnError {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got no error", testCase.testName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		if pipeline.IsADependencyGraph() != testCase.expectADependencyGraph {
+			t.Errorf("[%s] expected IsADependencyGraph() = %t, got %t", testCase.testName, testCase.expectADependencyGraph, pipeline.IsADependencyGraph())
+		}
+
+		if pipeline.NumberOfActions() != len(testCase.entries) {
+			t.Errorf("[%s] expected %d actions, got %d", testCase.testName, len(testCase.entries), pipeline.NumberOfActions())
+			continue
+		}
+
+		var lastAction *jobber.PipelineAction
+		for action := pipeline.Restart(); action != nil; action = pipeline.NextAction() {
+			lastAction = action
+		}
+
+		if diff := deep.Equal(lastAction.DependsOn, testCase.expectedDependsOnOfLastItem); diff != nil {
+			t.Errorf("[%s] DependsOn of last action: %v", testCase.testName, diff)
+		}
+	}
+}
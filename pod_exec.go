@@ -0,0 +1,120 @@
+package jobber
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// PodExecDefinition is the target of a pod-exec action, after template expansion.  Command is run in Container of
+// Pod.  If Namespace is empty, the default Namespace is used.  If Container is empty, the Pod must have only one
+// container.  If Stdin is not empty, it is delivered to the command on stdin.
+type PodExecDefinition struct {
+	Pod       string   `yaml:"Pod"`
+	Namespace string   `yaml:"Namespace"`
+	Container string   `yaml:"Container"`
+	Command   []string `yaml:"Command"`
+	Stdin     string   `yaml:"Stdin"`
+}
+
+// ParsePodExecDefinition decodes and validates a PodExecDefinition from yamlBytes.
+func ParsePodExecDefinition(yamlBytes []byte) (*PodExecDefinition, error) {
+	definition := new(PodExecDefinition)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(yamlBytes))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(definition); err != nil {
+		return nil, fmt.Errorf("failed to decode pod-exec definition: %s", err)
+	}
+
+	if definition.Pod == "" {
+		return nil, fmt.Errorf("pod-exec definition must have a Pod")
+	}
+
+	if len(definition.Command) == 0 || definition.Command[0] == "" {
+		return nil, fmt.Errorf("pod-exec definition must have a Command")
+	}
+
+	return definition, nil
+}
+
+// Description describes where the command of definition runs (e.g., "nginx/istio-proxy").
+func (definition *PodExecDefinition) Description() string {
+	if definition.Container == "" {
+		return definition.Pod
+	}
+
+	return fmt.Sprintf("%s/%s", definition.Pod, definition.Container)
+}
+
+// runPodExec expands the pod-exec definition template, then runs the command it describes.  The command output is
+// delivered in the same events as for an executable.
+func (action *PipelineAction) runPodExec(ctx context.Context, pipelineVariables *PipelineVariables, client *Client, eventChannel chan<- *ActionEvent) {
+	templateBuffer, err := action.expandDefinitionTemplate(pipelineVariables)
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: err,
+		}
+		return
+	}
+
+	eventChannel <- &ActionEvent{
+		Type:                   TemplateExpanded,
+		ExpandedTemplateBuffer: templateBuffer,
+	}
+
+	definition, err := ParsePodExecDefinition(templateBuffer.Bytes())
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: fmt.Errorf("in (%s): %s", action.ActionFullyQualifiedPath, err),
+		}
+		return
+	}
+
+	namespaceName := definition.Namespace
+	if namespaceName == "" {
+		namespaceName = pipelineVariables.Runtime.DefaultNamespace.Name
+	}
+
+	var stdin io.Reader
+	if definition.Stdin != "" {
+		stdin = strings.NewReader(definition.Stdin)
+	}
+
+	cmdStdout := new(bytes.Buffer)
+	cmdStderr := new(bytes.Buffer)
+
+	if err := client.ExecInContainer(ctx, namespaceName, definition.Pod, definition.Container, definition.Command, stdin, cmdStdout, cmdStderr); err != nil {
+		if exitError, isAnExitError := err.(utilexec.ExitError); isAnExitError {
+			err = fmt.Errorf("command in (%s) exited with code %d: %w", definition.Description(), exitError.ExitStatus(), err)
+		} else {
+			err = fmt.Errorf("failed to run command in (%s): %w", definition.Description(), explainedIfActionTimedOut(ctx, err))
+		}
+
+		eventChannel <- &ActionEvent{
+			Type:         AnErrorOccurred,
+			Error:        err,
+			StdoutBuffer: cmdStdout,
+			StderrBuffer: cmdStderr,
+		}
+		return
+	}
+
+	eventChannel <- &ActionEvent{
+		Type:         ExecutionSuccessful,
+		StdoutBuffer: cmdStdout,
+		StderrBuffer: cmdStderr,
+	}
+
+	eventChannel <- &ActionEvent{
+		Type: ActionCompletedSuccessfully,
+	}
+}
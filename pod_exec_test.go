@@ -0,0 +1,80 @@
+package jobber_test
+
+import (
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+)
+
+func TestPodExecDefinitions(t *testing.T) {
+	for _, testCase := range []struct {
+		testName           string
+		definition         string
+		expectAnError      bool
+		expectedDefinition *jobber.PodExecDefinition
+	}{
+		{
+			testName: "container and command",
+			definition: `
+Pod: nginx-producer
+Container: istio-proxy
+Command: [pilot-agent, request, GET, config_dump]
+`,
+			expectedDefinition: &jobber.PodExecDefinition{
+				Pod:       "nginx-producer",
+				Container: "istio-proxy",
+				Command:   []string{"pilot-agent", "request", "GET", "config_dump"},
+			},
+		},
+		{
+			testName: "namespace and stdin",
+			definition: `
+Pod: nginx-producer
+Namespace: shared
+Command: [sh]
+Stdin: |
+  curl -s localhost:15000/ready
+`,
+			expectedDefinition: &jobber.PodExecDefinition{
+				Pod:       "nginx-producer",
+				Namespace: "shared",
+				Command:   []string{"sh"},
+				Stdin:     "curl -s localhost:15000/ready\n",
+			},
+		},
+		{
+			testName:      "no Pod",
+			definition:    "Command: [ls]\n",
+			expectAnError: true,
+		},
+		{
+			testName:      "no Command",
+			definition:    "Pod: nginx-producer\n",
+			expectAnError: true,
+		},
+		{
+			testName:      "Command as a string",
+			definition:    "Pod: nginx-producer\nCommand: ls -l\n",
+			expectAnError: true,
+		},
+	} {
+		definition, err := jobber.ParsePodExecDefinition([]byte(testCase.definition))
+
+		if testCase.expectAnError {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got no error", testCase.testName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		if diff := deep.Equal(definition, testCase.expectedDefinition); diff != nil {
+			t.Errorf("[%s] %v", testCase.testName, diff)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package jobber
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podLogsDirectoryName is the name of the directory, in the assets directory of a Test Case iteration or of a
+// setup and teardown scope, that holds the logs of the containers of the Pods that were created.
+const podLogsDirectoryName = "pod-logs"
+
+// podLogCaptureTimeout bounds the retrieval of the log of a single container.
+const podLogCaptureTimeout = 2 * time.Minute
+
+// capturePodLogs writes the log of every container (including init and ephemeral containers) of every Pod in
+// resourceTracker that has not been deleted, and of every Pod owned by a Job in resourceTracker that has not been
+// deleted, to pod-logs/<pod>/<container>.log under paths.Root.  Capture proceeds even if ctx has been cancelled.
+// A log that cannot be captured is reported, but does not cause the Test Case to fail.
+func (runner *Runner) capturePodLogs(ctx context.Context, resourceTracker *CreatedResourceTracker, paths *TestCaseDirectoryPaths, eventHandler *eventHandler, testUnit *TestUnit, testCase *TestCase) {
+	runner.capturePodLogsUnder(ctx, resourceTracker, filepath.Join(paths.Root, podLogsDirectoryName), eventHandler, testUnit, testCase)
+}
+
+// captureFailedAttemptPodLogs captures the logs of the Pods and Jobs in attemptResourceTracker, which holds the
+// resources of a failed attempt (counting from 1) to run action, before they are deleted for a retry.  The logs are
+// written to pod-logs/<attempt asset name>/<pod>/<container>.log under paths.Root, so that they are kept apart
+// from those of other attempts.
+func (runner *Runner) captureFailedAttemptPodLogs(ctx context.Context, action *PipelineAction, attempt uint, attemptResourceTracker *CreatedResourceTracker, paths *TestCaseDirectoryPaths, eventHandler *eventHandler, testUnit *TestUnit, testCase *TestCase) {
+	runner.capturePodLogsUnder(ctx, attemptResourceTracker, filepath.Join(paths.Root, podLogsDirectoryName, action.assetNameForAttempt(attempt)), eventHandler, testUnit, testCase)
+}
+
+// capturePodLogsUnder writes the logs of the Pods in resourceTracker (as described for capturePodLogs) to
+// <pod>/<container>.log under podLogsRootPath.  If a container has restarted, the log of its previous instance is
+// also written, to <pod>/<container>.previous.log.
+func (runner *Runner) capturePodLogsUnder(ctx context.Context, resourceTracker *CreatedResourceTracker, podLogsRootPath string, eventHandler *eventHandler, testUnit *TestUnit, testCase *TestCase) {
+	captureCtx := context.WithoutCancel(ctx)
+
+	for _, pod := range runner.podsCreatedOrOwnedByJobsIn(captureCtx, resourceTracker, eventHandler, testUnit, testCase) {
+		podLogsDirectoryPath := filepath.Join(podLogsRootPath, pod.Name)
+		if err := os.MkdirAll(podLogsDirectoryPath, 0750); err != nil {
+			eventHandler.sayThatPodLogCaptureFailed(pod.Namespace, pod.Name, "", err, testUnit, testCase)
+			continue
+		}
+
+		capturedContainerNames := make([]string, 0)
+		restartedContainerNames := make([]string, 0)
+
+		for _, containerName := range containerNamesOf(pod) {
+			if err := runner.captureContainerLog(captureCtx, pod, containerName, false, filepath.Join(podLogsDirectoryPath, containerName+".log")); err != nil {
+				eventHandler.sayThatPodLogCaptureFailed(pod.Namespace, pod.Name, containerName, err, testUnit, testCase)
+				continue
+			}
+			capturedContainerNames = append(capturedContainerNames, containerName)
+
+			if restartCountOf(pod, containerName) == 0 {
+				continue
+			}
+
+			if err := runner.captureContainerLog(captureCtx, pod, containerName, true, filepath.Join(podLogsDirectoryPath, containerName+".previous.log")); err != nil {
+				eventHandler.sayThatPodLogCaptureFailed(pod.Namespace, pod.Name, containerName, fmt.Errorf("log of previous instance: %w", err), testUnit, testCase)
+				continue
+			}
+			restartedContainerNames = append(restartedContainerNames, containerName)
+		}
+
+		if len(capturedContainerNames) > 0 {
+			eventHandler.sayThatPodLogsWereCaptured(pod.Namespace, pod.Name, capturedContainerNames, restartedContainerNames, podLogsDirectoryPath, testUnit, testCase)
+		}
+	}
+}
+
+// captureContainerLog writes the log of the named container of pod to logFilePath.  If previous is true, the log
+// of the previous instance of the container is written instead.
+func (runner *Runner) captureContainerLog(ctx context.Context, pod *corev1.Pod, containerName string, previous bool, logFilePath string) error {
+	ctx, cancel := context.WithTimeout(ctx, podLogCaptureTimeout)
+	defer cancel()
+
+	logFile, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+
+	if previous {
+		err = runner.client.WritePreviousContainerLog(ctx, pod.Namespace, pod.Name, containerName, logFile)
+	} else {
+		err = runner.client.WriteContainerLog(ctx, pod.Namespace, pod.Name, containerName, logFile)
+	}
+	if closeErr := logFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// podsCreatedOrOwnedByJobsIn returns the Pods that are in resourceTracker, followed by the Pods owned by each Job
+// in resourceTracker, in order of creation of the tracked resources.  A Pod or Job that can no longer be
+// retrieved is reported and skipped.
+func (runner *Runner) podsCreatedOrOwnedByJobsIn(ctx context.Context, resourceTracker *CreatedResourceTracker, eventHandler *eventHandler, testUnit *TestUnit, testCase *TestCase) []*corev1.Pod {
+	pods := make([]*corev1.Pod, 0)
+
+	for _, resource := range resourceTracker.undeletedRuntimeResources() {
+		namespaceName, name := resource.NamespaceName(), resource.ApiObject().GetName()
+
+		switch resource.GvkString() {
+		case "v1/Pod":
+			pod, err := runner.client.Set().CoreV1().Pods(namespaceName).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				eventHandler.sayThatPodLogCaptureFailed(namespaceName, name, "", err, testUnit, testCase)
+				continue
+			}
+			pods = append(pods, pod)
+		case "batch/v1/Job":
+			jobPods, err := runner.client.PodsOwnedByJob(ctx, namespaceName, name)
+			if err != nil {
+				eventHandler.sayThatPodLogCaptureFailed(namespaceName, "", "", fmt.Errorf("cannot find Pods of Job (%s): %w", name, err), testUnit, testCase)
+				continue
+			}
+			pods = append(pods, jobPods...)
+		}
+	}
+
+	return pods
+}
+
+// containerNamesOf returns the names of the init containers, containers and ephemeral containers of pod, in that
+// order.
+func containerNamesOf(pod *corev1.Pod) []string {
+	names := make([]string, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers)+len(pod.Spec.EphemeralContainers))
+
+	for _, c := range pod.Spec.InitContainers {
+		names = append(names, c.Name)
+	}
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		names = append(names, c.Name)
+	}
+
+	return names
+}
+
+// restartCountOf returns the number of times the named container of pod has restarted, according to the status of
+// pod.
+func restartCountOf(pod *corev1.Pod, containerName string) int32 {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses} {
+		for _, status := range statuses {
+			if status.Name == containerName {
+				return status.RestartCount
+			}
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,239 @@
+package jobber_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func podWithContainers(initContainerNames []string, containerNames []string, ephemeralContainerNames []string) *corev1.Pod {
+	pod := new(corev1.Pod)
+	for _, name := range initContainerNames {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{Name: name})
+	}
+	for _, name := range containerNames {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: name})
+	}
+	for _, name := range ephemeralContainerNames {
+		pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: name}})
+	}
+	return pod
+}
+
+func TestContainerNamesOf(t *testing.T) {
+	for _, testCase := range []struct {
+		testName      string
+		pod           *corev1.Pod
+		expectedNames []string
+	}{
+		{
+			testName:      "containers only",
+			pod:           podWithContainers(nil, []string{"nginx", "envoy"}, nil),
+			expectedNames: []string{"nginx", "envoy"},
+		},
+		{
+			testName:      "init, regular and ephemeral containers",
+			pod:           podWithContainers([]string{"istio-init", "setup"}, []string{"jmeter"}, []string{"debugger"}),
+			expectedNames: []string{"istio-init", "setup", "jmeter", "debugger"},
+		},
+		{
+			testName:      "no containers",
+			pod:           podWithContainers(nil, nil, nil),
+			expectedNames: []string{},
+		},
+	} {
+		if diff := deep.Equal(jobber.ContainerNamesOf(testCase.pod), testCase.expectedNames); diff != nil {
+			t.Errorf("[%s] container names differ from those expected: %v", testCase.testName, diff)
+		}
+	}
+}
+
+func TestRestartCountOf(t *testing.T) {
+	pod := podWithContainers([]string{"setup"}, []string{"jmeter"}, []string{"debugger"})
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{Name: "setup", RestartCount: 2}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "jmeter", RestartCount: 1}}
+	pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{{Name: "debugger", RestartCount: 3}}
+
+	for _, testCase := range []struct {
+		containerName        string
+		expectedRestartCount int32
+	}{
+		{containerName: "setup", expectedRestartCount: 2},
+		{containerName: "jmeter", expectedRestartCount: 1},
+		{containerName: "debugger", expectedRestartCount: 3},
+		{containerName: "absent", expectedRestartCount: 0},
+	} {
+		if restartCount := jobber.RestartCountOf(pod, testCase.containerName); restartCount != testCase.expectedRestartCount {
+			t.Errorf("[%s] expected restart count (%d), got (%d)", testCase.containerName, testCase.expectedRestartCount, restartCount)
+		}
+	}
+
+	if restartCount := jobber.RestartCountOf(podWithContainers(nil, []string{"nginx"}, nil), "nginx"); restartCount != 0 {
+		t.Errorf("[pod without status] expected restart count (0), got (%d)", restartCount)
+	}
+}
+
+// trackerOfPodsAndJobsOnFakeApiServer stores a Pod with an init container and a restarted container, and a Job
+// whose Pod has a restarted init container, on fake, and returns a tracker holding the Pod and the Job.  A Pod
+// that neither is nor belongs to a tracked resource is stored too.
+func trackerOfPodsAndJobsOnFakeApiServer(t *testing.T, fake *fakeApiServer, client *jobber.Client) *jobber.CreatedResourceTracker {
+	podsPath := apiPathFor("v1", "perftest-x7k2", "pods")
+
+	nginx := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "nginx", "namespace": "perftest-x7k2"},
+		"spec": map[string]any{
+			"initContainers": []any{map[string]any{"name": "istio-init", "image": "proxy"}},
+			"containers":     []any{map[string]any{"name": "nginx", "image": "nginx"}},
+		},
+		"status": map[string]any{
+			"initContainerStatuses": []any{map[string]any{"name": "istio-init", "restartCount": 0}},
+			"containerStatuses":     []any{map[string]any{"name": "nginx", "restartCount": 1}},
+		},
+	}
+
+	jmeterJob := map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata":   map[string]any{"name": "jmeter", "namespace": "perftest-x7k2", "uid": "uid-jmeter"},
+		"spec": map[string]any{
+			"selector": map[string]any{"matchLabels": map[string]any{"job-name": "jmeter"}},
+			"template": map[string]any{"spec": map[string]any{"containers": []any{map[string]any{"name": "jmeter", "image": "jmeter"}}}},
+		},
+	}
+
+	fake.store(podsPath, nginx)
+	fake.store(apiPathFor("batch/v1", "perftest-x7k2", "jobs"), jmeterJob)
+	fake.store(podsPath, map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name":            "jmeter-x7k2p",
+			"namespace":       "perftest-x7k2",
+			"labels":          map[string]any{"job-name": "jmeter"},
+			"ownerReferences": []any{map[string]any{"apiVersion": "batch/v1", "kind": "Job", "name": "jmeter", "uid": "uid-jmeter"}},
+		},
+		"spec": map[string]any{
+			"initContainers": []any{map[string]any{"name": "setup", "image": "busybox"}},
+			"containers":     []any{map[string]any{"name": "jmeter", "image": "jmeter"}},
+		},
+		"status": map[string]any{
+			"initContainerStatuses": []any{map[string]any{"name": "setup", "restartCount": 2}},
+			"containerStatuses":     []any{map[string]any{"name": "jmeter", "restartCount": 0}},
+		},
+	})
+	fake.store(podsPath, map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "unrelated", "namespace": "perftest-x7k2", "labels": map[string]any{"app": "unrelated"}},
+		"spec":       map[string]any{"containers": []any{map[string]any{"name": "unrelated", "image": "nginx"}}},
+	})
+
+	tracker := jobber.NewCreatedResourceTracker()
+	for _, object := range []map[string]any{nginx, jmeterJob} {
+		resource, err := jobber.NewGenericK8sResourceFromUnstructured(&unstructured.Unstructured{Object: object}, client)
+		if err != nil {
+			t.Fatalf("expected no error, got error = (%s)", err)
+		}
+		tracker.AddCreatedResource(jobber.NewDeletableRuntimeResourceForTest(resource))
+	}
+
+	return tracker
+}
+
+// filesUnder returns the contents of each file under directoryPath, by path relative to directoryPath.
+func filesUnder(t *testing.T, directoryPath string) map[string]string {
+	files := make(map[string]string)
+
+	err := filepath.WalkDir(directoryPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		contents, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		relativePath, _ := filepath.Rel(directoryPath, filePath)
+		files[filepath.ToSlash(relativePath)] = string(contents)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("failed to read files under (%s): %s", directoryPath, err)
+	}
+
+	return files
+}
+
+func TestPodLogCapture(t *testing.T) {
+	expectedLogsByPod := map[string]string{
+		"nginx/istio-init.log":            "log of istio-init",
+		"nginx/nginx.log":                 "log of nginx",
+		"nginx/nginx.previous.log":        "previous log of nginx",
+		"jmeter-x7k2p/setup.log":          "log of setup",
+		"jmeter-x7k2p/setup.previous.log": "previous log of setup",
+		"jmeter-x7k2p/jmeter.log":         "log of jmeter",
+	}
+
+	failedAttempt, err := jobber.PipelineActionFromStringDescriptor("resources/pod01", "testing_assets")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+	failedAttempt.RetryPolicy = &jobber.ActionRetryPolicy{Retries: 2}
+
+	for _, testCase := range []struct {
+		testName              string
+		capture               func(runner *jobber.Runner, tracker *jobber.CreatedResourceTracker, paths *jobber.TestCaseDirectoryPaths, eventChannel chan<- *jobber.Event)
+		expectedLogsDirectory string
+	}{
+		{
+			testName: "Test Case",
+			capture: func(runner *jobber.Runner, tracker *jobber.CreatedResourceTracker, paths *jobber.TestCaseDirectoryPaths, eventChannel chan<- *jobber.Event) {
+				runner.CapturePodLogs(context.Background(), tracker, paths, eventChannel)
+			},
+			expectedLogsDirectory: "pod-logs",
+		},
+		{
+			testName: "failed attempt",
+			capture: func(runner *jobber.Runner, tracker *jobber.CreatedResourceTracker, paths *jobber.TestCaseDirectoryPaths, eventChannel chan<- *jobber.Event) {
+				runner.CaptureFailedAttemptPodLogs(context.Background(), failedAttempt, 1, tracker, paths, eventChannel)
+			},
+			expectedLogsDirectory: "pod-logs/pod01.attempt-1",
+		},
+	} {
+		fake, client := newFakeApiServer(t)
+		tracker := trackerOfPodsAndJobsOnFakeApiServer(t, fake, client)
+		paths := &jobber.TestCaseDirectoryPaths{Root: t.TempDir()}
+
+		eventChannel := make(chan *jobber.Event)
+		go func() {
+			testCase.capture(jobber.NewRunner(GenerateTestConfiguration(), client), tracker, paths, eventChannel)
+			close(eventChannel)
+		}()
+
+		for event := range eventChannel {
+			if event.Type == jobber.PodLogCaptureFailed {
+				t.Errorf("[%s] expected no failure to capture logs, got error = (%s)", testCase.testName, event.Error)
+			}
+		}
+
+		expectedFiles := make(map[string]string)
+		for logPath, contents := range expectedLogsByPod {
+			expectedFiles[testCase.expectedLogsDirectory+"/"+logPath] = contents
+		}
+
+		if diff := deep.Equal(filesUnder(t, paths.Root), expectedFiles); diff != nil {
+			t.Errorf("[%s] captured logs differ from those expected: %v", testCase.testName, diff)
+		}
+	}
+}
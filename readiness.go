@@ -0,0 +1,329 @@
+package jobber
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// WaitForReadinessAnnotation, on a resource in a resources template, determines whether the Pipeline Action waits
+// for the resource to be ready.  If it is "false", the action does not wait, even for a kind that is normally
+// waited for.  If it is "true", the action waits for a kind that is not normally waited for to have a Ready
+// condition with status True.
+const WaitForReadinessAnnotation = "jobber.blorticus-go.github.io/wait-for-readiness"
+
+// readyConditionGracePeriod is how long, after a resource of a kind that Kubernetes does not define is created, the
+// Pipeline Action waits for the resource to acquire a Ready condition.  A controller sets the condition only once
+// it has seen the resource, so it is never present on the resource as submitted.
+const readyConditionGracePeriod = 10 * time.Second
+
+// readinessAnnotationOf returns the value of the WaitForReadinessAnnotation of object, and whether it is set.
+func readinessAnnotationOf(object *unstructured.Unstructured) (awaits bool, isSet bool, err error) {
+	value, isSet := object.GetAnnotations()[WaitForReadinessAnnotation]
+	if !isSet {
+		return false, false, nil
+	}
+
+	if awaits, err = strconv.ParseBool(value); err != nil {
+		return false, false, fmt.Errorf("annotation (%s) on resource (%s) must be true or false", WaitForReadinessAnnotation, object.GetName())
+	}
+
+	return awaits, true, nil
+}
+
+// awaitsReadiness returns true if, once resource is created, the Pipeline Action waits for it to be ready when the
+// WaitForReadinessAnnotation does not say.  This is so for a Deployment, StatefulSet, DaemonSet, Service or
+// PersistentVolumeClaim, and never so for any other kind that Kubernetes defines.  A resource of any other kind
+// (e.g., a custom resource) is retrieved every probeInterval until it has a Ready condition, and is waited for if
+// one appears within the readyConditionGracePeriod.
+func awaitsReadiness(ctx context.Context, resource *GenericK8sResource, probeInterval time.Duration) (bool, error) {
+	switch resource.GvkString() {
+	case "apps/v1/Deployment", "apps/v1/StatefulSet", "apps/v1/DaemonSet", "v1/Service", "v1/PersistentVolumeClaim":
+		return true, nil
+	}
+
+	if scheme.Scheme.Recognizes(resource.ApiObject().GroupVersionKind()) {
+		return false, nil
+	}
+
+	err := NewWaitTimer(readyConditionGracePeriod, probeInterval).TestExpectation(
+		ctx,
+		resource,
+		func(objectToTest Updatable) (expectationReached bool, errorOccurred error) {
+			_, hasAReadyCondition := readyConditionOf(resource.ApiObject())
+			return hasAReadyCondition, nil
+		},
+	)
+
+	switch {
+	case err == nil:
+		return true, nil
+	case err == ErrorTimeExceeded:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to learn whether resource (%s) has a Ready condition: %w", resource.Information().Name, err)
+	}
+}
+
+// ReadinessOf reports whether object is ready.  A Deployment, StatefulSet or DaemonSet is ready when its rollout is
+// complete and every desired replica is available.  A PersistentVolumeClaim is ready when it is Bound.  Any other
+// object is ready when it has a Ready condition with status True that reflects the latest spec (that is, whose
+// observedGeneration, or else that of the status, is not less than the generation of object).  (A Service is ready according to
+// ServiceReadinessOf.)  If object is not ready, the reason is returned.  An error is returned if object cannot
+// become ready, as when a Deployment exceeds its progress deadline.
+func ReadinessOf(object *unstructured.Unstructured) (isReady bool, notReadyBecause string, err error) {
+	gvk := object.GroupVersionKind()
+
+	switch fmt.Sprintf("%s/%s", gvk.GroupVersion().String(), gvk.Kind) {
+	case "apps/v1/Deployment":
+		deployment := new(appsv1.Deployment)
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, deployment); err != nil {
+			return false, "", fmt.Errorf("cannot convert generic API object to Deployment API object: %s", err)
+		}
+		return deploymentReadiness(deployment)
+
+	case "apps/v1/StatefulSet":
+		statefulSet := new(appsv1.StatefulSet)
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, statefulSet); err != nil {
+			return false, "", fmt.Errorf("cannot convert generic API object to StatefulSet API object: %s", err)
+		}
+		return statefulSetReadiness(statefulSet)
+
+	case "apps/v1/DaemonSet":
+		daemonSet := new(appsv1.DaemonSet)
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, daemonSet); err != nil {
+			return false, "", fmt.Errorf("cannot convert generic API object to DaemonSet API object: %s", err)
+		}
+		return daemonSetReadiness(daemonSet)
+
+	case "v1/PersistentVolumeClaim":
+		phase, _, _ := unstructured.NestedString(object.Object, "status", "phase")
+		if phase != string(corev1.ClaimBound) {
+			return false, fmt.Sprintf("phase is (%s), not Bound", phase), nil
+		}
+		return true, "", nil
+
+	default:
+		condition, hasAReadyCondition := readyConditionOf(object)
+		if !hasAReadyCondition {
+			return false, "there is no Ready condition", nil
+		}
+
+		observedGeneration, isSet, _ := unstructured.NestedInt64(condition, "observedGeneration")
+		if !isSet {
+			observedGeneration, isSet, _ = unstructured.NestedInt64(object.Object, "status", "observedGeneration")
+		}
+		if isSet && observedGeneration < object.GetGeneration() {
+			return false, "the Ready condition does not reflect the latest spec", nil
+		}
+
+		if status, _ := condition["status"].(string); status != string(metav1.ConditionTrue) {
+			return false, fmt.Sprintf("Ready condition status is (%s)", status), nil
+		}
+		return true, "", nil
+	}
+}
+
+func deploymentReadiness(deployment *appsv1.Deployment) (bool, string, error) {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return false, "", fmt.Errorf("Deployment (%s) exceeded its progress deadline", deployment.Name)
+		}
+	}
+
+	desiredReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desiredReplicas = *deployment.Spec.Replicas
+	}
+
+	switch {
+	case deployment.Status.ObservedGeneration < deployment.Generation:
+		return false, "the latest spec has not been observed", nil
+	case deployment.Status.UpdatedReplicas < desiredReplicas:
+		return false, fmt.Sprintf("%d of %d replicas are updated", deployment.Status.UpdatedReplicas, desiredReplicas), nil
+	case deployment.Status.Replicas > deployment.Status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas), nil
+	case deployment.Status.AvailableReplicas < desiredReplicas:
+		return false, fmt.Sprintf("%d of %d replicas are available", deployment.Status.AvailableReplicas, desiredReplicas), nil
+	default:
+		return true, "", nil
+	}
+}
+
+func statefulSetReadiness(statefulSet *appsv1.StatefulSet) (bool, string, error) {
+	desiredReplicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		desiredReplicas = *statefulSet.Spec.Replicas
+	}
+
+	replicasToUpdate := desiredReplicas
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		replicasToUpdate -= *rollingUpdate.Partition
+	}
+
+	switch {
+	case statefulSet.Status.ObservedGeneration < statefulSet.Generation:
+		return false, "the latest spec has not been observed", nil
+	case statefulSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType && statefulSet.Status.UpdatedReplicas < replicasToUpdate:
+		return false, fmt.Sprintf("%d of %d replicas are updated", statefulSet.Status.UpdatedReplicas, replicasToUpdate), nil
+	case statefulSet.Status.ReadyReplicas < desiredReplicas:
+		return false, fmt.Sprintf("%d of %d replicas are ready", statefulSet.Status.ReadyReplicas, desiredReplicas), nil
+	case statefulSet.Status.AvailableReplicas < desiredReplicas:
+		return false, fmt.Sprintf("%d of %d replicas are available", statefulSet.Status.AvailableReplicas, desiredReplicas), nil
+	default:
+		return true, "", nil
+	}
+}
+
+func daemonSetReadiness(daemonSet *appsv1.DaemonSet) (bool, string, error) {
+	desired := daemonSet.Status.DesiredNumberScheduled
+
+	switch {
+	case daemonSet.Status.ObservedGeneration < daemonSet.Generation:
+		return false, "the latest spec has not been observed", nil
+	case daemonSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType && daemonSet.Status.UpdatedNumberScheduled < desired:
+		return false, fmt.Sprintf("%d of %d Pods are updated", daemonSet.Status.UpdatedNumberScheduled, desired), nil
+	case daemonSet.Status.NumberAvailable < desired:
+		return false, fmt.Sprintf("%d of %d Pods are available", daemonSet.Status.NumberAvailable, desired), nil
+	default:
+		return true, "", nil
+	}
+}
+
+// ServiceReadinessOf reports whether service, whose EndpointSlices are endpointSlices, is ready.  A Service with a
+// selector is ready when at least one of its endpoints is ready.  A Service without a selector (including an
+// ExternalName Service) has no endpoints that Kubernetes manages, so it is always ready.
+func ServiceReadinessOf(service *unstructured.Unstructured, endpointSlices []discoveryv1.EndpointSlice) (isReady bool, notReadyBecause string) {
+	if selector, _, _ := unstructured.NestedStringMap(service.Object, "spec", "selector"); len(selector) == 0 {
+		return true, ""
+	}
+
+	if serviceType, _, _ := unstructured.NestedString(service.Object, "spec", "type"); serviceType == string(corev1.ServiceTypeExternalName) {
+		return true, ""
+	}
+
+	for _, endpointSlice := range endpointSlices {
+		for _, endpoint := range endpointSlice.Endpoints {
+			// A nil Ready is to be understood as ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				return true, ""
+			}
+		}
+	}
+
+	return false, "no endpoints are ready"
+}
+
+func readyConditionOf(object *unstructured.Unstructured) (condition map[string]any, hasAReadyCondition bool) {
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+
+	for _, c := range conditions {
+		condition, isAMap := c.(map[string]any)
+		if isAMap && condition["type"] == "Ready" {
+			return condition, true
+		}
+	}
+
+	return nil, false
+}
+
+// resourceReadiness is the Updatable for a readiness wait.  Updating it retrieves the resource and, for a Service,
+// its EndpointSlices.  For a PersistentVolumeClaim whose StorageClass binds volumes only once a Pod uses the claim,
+// there is nothing to wait for.
+type resourceReadiness struct {
+	resource                    *GenericK8sResource
+	client                      *Client
+	endpointSlices              []discoveryv1.EndpointSlice
+	storageClassWasChecked      bool
+	claimIsBoundOnFirstConsumer bool
+	notReadyBecause             string
+}
+
+func (readiness *resourceReadiness) UpdateStatus(ctx context.Context) error {
+	if err := readiness.resource.UpdateStatus(ctx); err != nil {
+		return err
+	}
+
+	switch readiness.resource.GvkString() {
+	case "v1/Service":
+		endpointSliceList, err := readiness.client.Set().DiscoveryV1().EndpointSlices(readiness.resource.NamespaceName()).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, readiness.resource.Name),
+		})
+		if err != nil {
+			return err
+		}
+		readiness.endpointSlices = endpointSliceList.Items
+
+	case "v1/PersistentVolumeClaim":
+		if readiness.storageClassWasChecked {
+			return nil
+		}
+
+		storageClassName, _, _ := unstructured.NestedString(readiness.resource.ApiObject().Object, "spec", "storageClassName")
+		if storageClassName == "" {
+			readiness.storageClassWasChecked = true
+			return nil
+		}
+
+		storageClass, err := readiness.client.Set().StorageV1().StorageClasses().Get(ctx, storageClassName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		readiness.storageClassWasChecked = true
+		readiness.claimIsBoundOnFirstConsumer = storageClass.VolumeBindingMode != nil && *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer
+	}
+
+	return nil
+}
+
+func (readiness *resourceReadiness) isReady() (bool, error) {
+	var isReady bool
+	var err error
+
+	switch {
+	case readiness.resource.GvkString() == "v1/Service":
+		isReady, readiness.notReadyBecause = ServiceReadinessOf(readiness.resource.ApiObject(), readiness.endpointSlices)
+	case readiness.claimIsBoundOnFirstConsumer:
+		isReady = true
+	default:
+		isReady, readiness.notReadyBecause, err = ReadinessOf(readiness.resource.ApiObject())
+	}
+
+	return isReady, err
+}
+
+// WaitForReadiness checks the resource every probeInterval until it is ready (see ReadinessOf and
+// ServiceReadinessOf).  If that takes longer than lengthOfTimeToWait, an error wrapping ErrorTimeExceeded, and
+// explaining why the resource is not ready, is returned.  If ctx is cancelled first, the ctx error is returned.
+func (resource *GenericK8sResource) WaitForReadiness(ctx context.Context, lengthOfTimeToWait time.Duration, probeInterval time.Duration) error {
+	readiness := &resourceReadiness{resource: resource, client: resource.client}
+	timer := NewWaitTimer(lengthOfTimeToWait, probeInterval)
+
+	err := timer.TestExpectation(
+		ctx,
+		readiness,
+		func(objectToTest Updatable) (expectationReached bool, errorOccurred error) {
+			return readiness.isReady()
+		},
+	)
+
+	if err == ErrorTimeExceeded {
+		if readiness.notReadyBecause == "" {
+			return fmt.Errorf("%w: %s did not become ready within %s", ErrorTimeExceeded, resource.Kind, lengthOfTimeToWait)
+		}
+		return fmt.Errorf("%w: %s did not become ready within %s (%s)", ErrorTimeExceeded, resource.Kind, lengthOfTimeToWait, readiness.notReadyBecause)
+	}
+
+	return err
+}
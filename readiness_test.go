@@ -0,0 +1,223 @@
+package jobber_test
+
+import (
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestReadinessOf(t *testing.T) {
+	object := func(apiVersion string, kind string, spec map[string]any, status map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata":   map[string]any{"name": "server", "generation": int64(2)},
+			"spec":       spec,
+			"status":     status,
+		}}
+	}
+
+	for _, testCase := range []struct {
+		testName      string
+		object        *unstructured.Unstructured
+		expectReady   bool
+		expectAnError bool
+	}{
+		{
+			testName: "Deployment with all replicas available",
+			object: object("apps/v1", "Deployment", map[string]any{"replicas": int64(3)}, map[string]any{
+				"observedGeneration": int64(2), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3),
+			}),
+			expectReady: true,
+		},
+		{
+			testName: "Deployment whose latest spec has not been observed",
+			object: object("apps/v1", "Deployment", map[string]any{"replicas": int64(3)}, map[string]any{
+				"observedGeneration": int64(1), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3),
+			}),
+		},
+		{
+			testName: "Deployment with old replicas pending termination",
+			object: object("apps/v1", "Deployment", map[string]any{"replicas": int64(3)}, map[string]any{
+				"observedGeneration": int64(2), "replicas": int64(4), "updatedReplicas": int64(3), "availableReplicas": int64(3),
+			}),
+		},
+		{
+			testName: "Deployment with default replicas not yet available",
+			object: object("apps/v1", "Deployment", map[string]any{}, map[string]any{
+				"observedGeneration": int64(2), "replicas": int64(1), "updatedReplicas": int64(1),
+			}),
+		},
+		{
+			testName: "Deployment that exceeded its progress deadline",
+			object: object("apps/v1", "Deployment", map[string]any{"replicas": int64(3)}, map[string]any{
+				"observedGeneration": int64(2),
+				"conditions": []any{
+					map[string]any{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"},
+				},
+			}),
+			expectAnError: true,
+		},
+		{
+			testName: "StatefulSet with all replicas ready and available",
+			object: object("apps/v1", "StatefulSet", map[string]any{"replicas": int64(2)}, map[string]any{
+				"observedGeneration": int64(2), "updatedReplicas": int64(2), "readyReplicas": int64(2), "availableReplicas": int64(2),
+			}),
+			expectReady: true,
+		},
+		{
+			testName: "StatefulSet with a replica not ready",
+			object: object("apps/v1", "StatefulSet", map[string]any{"replicas": int64(2)}, map[string]any{
+				"observedGeneration": int64(2), "updatedReplicas": int64(2), "readyReplicas": int64(1), "availableReplicas": int64(1),
+			}),
+		},
+		{
+			testName: "DaemonSet with all Pods available",
+			object: object("apps/v1", "DaemonSet", map[string]any{}, map[string]any{
+				"observedGeneration": int64(2), "desiredNumberScheduled": int64(4), "updatedNumberScheduled": int64(4), "numberAvailable": int64(4),
+			}),
+			expectReady: true,
+		},
+		{
+			testName: "DaemonSet with a Pod not available",
+			object: object("apps/v1", "DaemonSet", map[string]any{}, map[string]any{
+				"observedGeneration": int64(2), "desiredNumberScheduled": int64(4), "updatedNumberScheduled": int64(4), "numberAvailable": int64(3),
+			}),
+		},
+		{
+			testName:    "Bound PersistentVolumeClaim",
+			object:      object("v1", "PersistentVolumeClaim", map[string]any{}, map[string]any{"phase": "Bound"}),
+			expectReady: true,
+		},
+		{
+			testName: "Pending PersistentVolumeClaim",
+			object:   object("v1", "PersistentVolumeClaim", map[string]any{}, map[string]any{"phase": "Pending"}),
+		},
+		{
+			testName: "resource with a True Ready condition",
+			object: object("cert-manager.io/v1", "Certificate", map[string]any{}, map[string]any{
+				"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+			}),
+			expectReady: true,
+		},
+		{
+			testName: "resource with a False Ready condition",
+			object: object("cert-manager.io/v1", "Certificate", map[string]any{}, map[string]any{
+				"conditions": []any{map[string]any{"type": "Ready", "status": "False"}},
+			}),
+		},
+		{
+			testName: "resource with a True Ready condition that observed the latest generation",
+			object: object("cert-manager.io/v1", "Certificate", map[string]any{}, map[string]any{
+				"conditions": []any{map[string]any{"type": "Ready", "status": "True", "observedGeneration": int64(2)}},
+			}),
+			expectReady: true,
+		},
+		{
+			testName: "resource with a True Ready condition that observed an earlier generation",
+			object: object("cert-manager.io/v1", "Certificate", map[string]any{}, map[string]any{
+				"conditions": []any{map[string]any{"type": "Ready", "status": "True", "observedGeneration": int64(1)}},
+			}),
+		},
+		{
+			testName: "resource with a True Ready condition whose status observed an earlier generation",
+			object: object("cert-manager.io/v1", "Certificate", map[string]any{}, map[string]any{
+				"observedGeneration": int64(1),
+				"conditions":         []any{map[string]any{"type": "Ready", "status": "True"}},
+			}),
+		},
+		{
+			testName: "resource without a Ready condition",
+			object:   object("cert-manager.io/v1", "Certificate", map[string]any{}, map[string]any{}),
+		},
+	} {
+		isReady, notReadyBecause, err := jobber.ReadinessOf(testCase.object)
+
+		if testCase.expectAnError {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got no error", testCase.testName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		if isReady != testCase.expectReady {
+			t.Errorf("[%s] expected ready = (%t), got (%t)", testCase.testName, testCase.expectReady, isReady)
+		}
+
+		if !isReady && notReadyBecause == "" {
+			t.Errorf("[%s] expected a reason for not being ready, got none", testCase.testName)
+		}
+	}
+}
+
+func TestServiceReadinessOf(t *testing.T) {
+	service := func(spec map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   map[string]any{"name": "server"},
+			"spec":       spec,
+		}}
+	}
+
+	isTrue, isFalse := true, false
+
+	for _, testCase := range []struct {
+		testName       string
+		service        *unstructured.Unstructured
+		endpointSlices []discoveryv1.EndpointSlice
+		expectReady    bool
+	}{
+		{
+			testName:    "Service without a selector",
+			service:     service(map[string]any{"ports": []any{map[string]any{"port": int64(80)}}}),
+			expectReady: true,
+		},
+		{
+			testName:    "ExternalName Service",
+			service:     service(map[string]any{"type": "ExternalName", "externalName": "example.com", "selector": map[string]any{"app": "server"}}),
+			expectReady: true,
+		},
+		{
+			testName: "Service with no EndpointSlices",
+			service:  service(map[string]any{"selector": map[string]any{"app": "server"}}),
+		},
+		{
+			testName: "Service with no ready endpoints",
+			service:  service(map[string]any{"selector": map[string]any{"app": "server"}}),
+			endpointSlices: []discoveryv1.EndpointSlice{
+				{Endpoints: []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: &isFalse}}}},
+			},
+		},
+		{
+			testName: "Service with a ready endpoint",
+			service:  service(map[string]any{"selector": map[string]any{"app": "server"}}),
+			endpointSlices: []discoveryv1.EndpointSlice{
+				{Endpoints: []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: &isFalse}}}},
+				{Endpoints: []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: &isTrue}}}},
+			},
+			expectReady: true,
+		},
+		{
+			testName: "Service with an endpoint of unknown readiness",
+			service:  service(map[string]any{"selector": map[string]any{"app": "server"}}),
+			endpointSlices: []discoveryv1.EndpointSlice{
+				{Endpoints: []discoveryv1.Endpoint{{}}},
+			},
+			expectReady: true,
+		},
+	} {
+		isReady, _ := jobber.ServiceReadinessOf(testCase.service, testCase.endpointSlices)
+
+		if isReady != testCase.expectReady {
+			t.Errorf("[%s] expected ready = (%t), got (%t)", testCase.testName, testCase.expectReady, isReady)
+		}
+	}
+}
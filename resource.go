@@ -9,10 +9,12 @@ import (
 	authenticationv1 "k8s.io/api/authentication/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type GenericK8sResource struct {
@@ -21,10 +23,33 @@ type GenericK8sResource struct {
 	Kind                  string
 	Name                  string
 	groupVersionResource  schema.GroupVersionResource
+	isNamespaced          bool
 	unstructuredApiObject *unstructured.Unstructured
 	client                *Client
 }
 
+// jobberAnnotationPrefix is the prefix of the annotations (e.g., ResourceModeAnnotation) with which a resource in a
+// resources template instructs jobber.
+const jobberAnnotationPrefix = "jobber.blorticus-go.github.io/"
+
+// withoutJobberAnnotations removes every annotation with the jobberAnnotationPrefix from object, since those are
+// meant for jobber and not for the API server.
+func withoutJobberAnnotations(object *unstructured.Unstructured) {
+	annotations := object.GetAnnotations()
+	for name := range annotations {
+		if strings.HasPrefix(name, jobberAnnotationPrefix) {
+			delete(annotations, name)
+		}
+	}
+
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(object.Object, "metadata", "annotations")
+		return
+	}
+
+	object.SetAnnotations(annotations)
+}
+
 func GuessResourceFromKind(kind string) string {
 	return fmt.Sprintf("%ss", strings.ToLower(kind))
 }
@@ -32,7 +57,7 @@ func GuessResourceFromKind(kind string) string {
 func NewGenericK8sResourceFromUnstructured(u *unstructured.Unstructured, client *Client) (*GenericK8sResource, error) {
 	gvk := u.GroupVersionKind()
 
-	gvr, err := client.DetermineResourceFromGroupVersionKind(gvk)
+	gvr, isNamespaced, err := client.DetermineResourceAndScopeFromGroupVersionKind(gvk)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +68,7 @@ func NewGenericK8sResourceFromUnstructured(u *unstructured.Unstructured, client
 		Kind:                  gvk.Kind,
 		Name:                  u.GetName(),
 		groupVersionResource:  gvr,
+		isNamespaced:          isNamespaced,
 		unstructuredApiObject: u,
 		client:                client,
 	}, nil
@@ -66,22 +92,44 @@ func NewGenericK8sResourceFromUnstructuredMap(inputMap map[string]any, client *C
 	return NewGenericK8sResourceFromUnstructured(candidate, client)
 }
 
+// NamespaceName returns the Namespace of the resource, which is empty if the kind is cluster-scoped, even if the
+// object sets one.
 func (resource *GenericK8sResource) NamespaceName() string {
+	if !resource.isNamespaced {
+		return ""
+	}
 	return resource.unstructuredApiObject.GetNamespace()
 }
 
+// IsNamespaced returns true if objects of the kind of the resource are in a Namespace, or false if they are
+// cluster-scoped.
+func (resource *GenericK8sResource) IsNamespaced() bool {
+	return resource.isNamespaced
+}
+
 func (resource *GenericK8sResource) SetNamespace(namespaceName string) {
 	resource.unstructuredApiObject.SetNamespace(namespaceName)
 }
 
-func (resource *GenericK8sResource) Create() (err error) {
+func (resource *GenericK8sResource) Create(ctx context.Context) (err error) {
+	return resource.createWithOptions(ctx, metav1.CreateOptions{})
+}
+
+// CreateInDryRun submits the resource to the API server with server-side dry run, so that it is validated and
+// admitted (or rejected) but is not persisted.  The resource is updated to the object that the API server would
+// have created.
+func (resource *GenericK8sResource) CreateInDryRun(ctx context.Context) (err error) {
+	return resource.createWithOptions(ctx, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+}
+
+func (resource *GenericK8sResource) createWithOptions(ctx context.Context, createOptions metav1.CreateOptions) (err error) {
 	updatedResource, err := resource.client.Dynamic().
 		Resource(resource.groupVersionResource).
 		Namespace(resource.NamespaceName()).
 		Create(
-			context.Background(),
+			ctx,
 			resource.unstructuredApiObject,
-			metav1.CreateOptions{},
+			createOptions,
 		)
 
 	if err != nil {
@@ -92,12 +140,56 @@ func (resource *GenericK8sResource) Create() (err error) {
 	return nil
 }
 
-func (resource *GenericK8sResource) UpdateStatus() (err error) {
+// Apply submits the resource with server-side apply, as the jobber field manager, creating the object if it does
+// not exist.  Fields of the resource that other field managers own are taken over.  If the object already existed,
+// it is returned as it was before, so that it can be restored; otherwise, nil is returned.  The resource is updated
+// to the object that results.
+func (resource *GenericK8sResource) Apply(ctx context.Context) (existingObject *unstructured.Unstructured, err error) {
+	return resource.applyWithOptions(ctx, metav1.PatchOptions{})
+}
+
+// ApplyInDryRun submits the resource with server-side apply and server-side dry run, so that it is validated and
+// admitted (or rejected) but is not persisted.
+func (resource *GenericK8sResource) ApplyInDryRun(ctx context.Context) (err error) {
+	_, err = resource.applyWithOptions(ctx, metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}})
+	return err
+}
+
+func (resource *GenericK8sResource) applyWithOptions(ctx context.Context, patchOptions metav1.PatchOptions) (existingObject *unstructured.Unstructured, err error) {
+	resourceInterface := resource.client.Dynamic().Resource(resource.groupVersionResource).Namespace(resource.NamespaceName())
+
+	existingObject, err = resourceInterface.Get(ctx, resource.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		existingObject = nil
+	}
+
+	applyConfiguration, err := resource.unstructuredApiObject.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	forceOwnership := true
+	patchOptions.FieldManager = jobberFieldManager
+	patchOptions.Force = &forceOwnership
+
+	updatedResource, err := resourceInterface.Patch(ctx, resource.Name, types.ApplyPatchType, applyConfiguration, patchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	resource.unstructuredApiObject = updatedResource
+	return existingObject, nil
+}
+
+func (resource *GenericK8sResource) UpdateStatus(ctx context.Context) (err error) {
 	updatedResource, err := resource.client.Dynamic().
 		Resource(resource.groupVersionResource).
 		Namespace(resource.NamespaceName()).
 		Get(
-			context.Background(),
+			ctx,
 			resource.Name,
 			metav1.GetOptions{},
 		)
@@ -110,12 +202,12 @@ func (resource *GenericK8sResource) UpdateStatus() (err error) {
 	return nil
 }
 
-func (resource *GenericK8sResource) Delete() error {
+func (resource *GenericK8sResource) Delete(ctx context.Context) error {
 	return resource.client.Dynamic().
 		Resource(resource.groupVersionResource).
 		Namespace(resource.NamespaceName()).
 		Delete(
-			context.Background(),
+			ctx,
 			resource.Name,
 			resource.client.DefaultResourceDeletionOptions(),
 		)
@@ -174,8 +266,8 @@ func (resource *GenericK8sResource) AsAJob() *TransitiveJob {
 	}
 }
 
-func (pod *TransitivePod) UpdateStatus() (err error) {
-	return pod.genericResource.UpdateStatus()
+func (pod *TransitivePod) UpdateStatus(ctx context.Context) (err error) {
+	return pod.genericResource.UpdateStatus(ctx)
 }
 
 func (pod *TransitivePod) typedApiObject() (*corev1.Pod, error) {
@@ -184,10 +276,13 @@ func (pod *TransitivePod) typedApiObject() (*corev1.Pod, error) {
 	return typed, err
 }
 
-func (pod *TransitivePod) WaitForRunningState(lengthOfTimeToWait time.Duration) error {
-	timer := NewWaitTimer(lengthOfTimeToWait, time.Second)
+// WaitForRunningState checks the Pod status every probeInterval until the Pod is Running.  If that takes longer
+// than lengthOfTimeToWait, ErrorTimeExceeded is returned.  If ctx is cancelled first, the ctx error is returned.
+func (pod *TransitivePod) WaitForRunningState(ctx context.Context, lengthOfTimeToWait time.Duration, probeInterval time.Duration) error {
+	timer := NewWaitTimer(lengthOfTimeToWait, probeInterval)
 
 	return timer.TestExpectation(
+		ctx,
 		pod,
 		func(objectToTest Updatable) (expectationReached bool, errorOccurred error) {
 			podApiObject, err := pod.typedApiObject()
@@ -215,34 +310,36 @@ func (job *TransitiveJob) typedApiObject() (*batchv1.Job, error) {
 
 }
 
-func (job *TransitiveJob) updateJobApiObjectStatus(apiObject *batchv1.Job) (*batchv1.Job, error) {
-	return job.client.clientSet.BatchV1().Jobs(apiObject.Namespace).Get(context.Background(), apiObject.Name, metav1.GetOptions{})
+func (job *TransitiveJob) UpdateStatus(ctx context.Context) (err error) {
+	return job.genericResource.UpdateStatus(ctx)
 }
 
-func (job *TransitiveJob) WaitForCompletion() error {
-	jobApiObject, err := job.typedApiObject()
-	if err != nil {
-		return fmt.Errorf("cannot convert generic API object to Job API object: %s", err)
-	}
-
-	ticker := time.NewTicker(10 * time.Second)
+// WaitForCompletion checks the Job status every probeInterval until the Job completes or one of its Pods fails.
+// If that takes longer than lengthOfTimeToWait, ErrorTimeExceeded is returned.  If lengthOfTimeToWait is zero,
+// there is no limit.  If ctx is cancelled first, the ctx error is returned.
+func (job *TransitiveJob) WaitForCompletion(ctx context.Context, lengthOfTimeToWait time.Duration, probeInterval time.Duration) error {
+	timer := NewWaitTimer(lengthOfTimeToWait, probeInterval)
 
-	for {
-		<-ticker.C
+	return timer.TestExpectation(
+		ctx,
+		job,
+		func(objectToTest Updatable) (expectationReached bool, errorOccurred error) {
+			jobApiObject, err := job.typedApiObject()
+			if err != nil {
+				return false, fmt.Errorf("cannot convert generic API object to Job API object: %s", err)
+			}
 
-		jobApiObject, err := job.updateJobApiObjectStatus(jobApiObject)
-		if err != nil {
-			return fmt.Errorf("failed to update Job status: %s", err)
-		}
+			if jobApiObject.Status.CompletionTime != nil {
+				return true, nil
+			}
 
-		if jobApiObject.Status.CompletionTime != nil {
-			return nil
-		}
+			if jobApiObject.Status.Failed > 0 {
+				return false, fmt.Errorf("[%d] Pods for the Job failed", jobApiObject.Status.Failed)
+			}
 
-		if jobApiObject.Status.Failed > 0 {
-			return fmt.Errorf("[%d] Pods for the Job failed", jobApiObject.Status.Failed)
-		}
-	}
+			return false, nil
+		},
+	)
 }
 
 func (sa *TransitiveServiceAccount) GenerateBoundBearerTokenString() (string, error) {
@@ -0,0 +1,82 @@
+package jobber_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestApplyUsesTheScopeOfTheKind(t *testing.T) {
+	for _, testCase := range []struct {
+		testName             string
+		object               map[string]any
+		namespaceToSet       string
+		expectedNamespace    string
+		expectedObjectPath   string
+		expectedIsNamespaced bool
+	}{
+		{
+			testName: "cluster-scoped CustomResourceDefinition",
+			object: map[string]any{
+				"apiVersion": "apiextensions.k8s.io/v1",
+				"kind":       "CustomResourceDefinition",
+				"metadata":   map[string]any{"name": "loadtests.example.com"},
+			},
+			expectedObjectPath: "/apis/apiextensions.k8s.io/v1/customresourcedefinitions/loadtests.example.com",
+		},
+		{
+			testName: "namespaced ConfigMap",
+			object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": "settings"},
+			},
+			namespaceToSet:       "perftest-x7k2",
+			expectedNamespace:    "perftest-x7k2",
+			expectedObjectPath:   "/api/v1/namespaces/perftest-x7k2/configmaps/settings",
+			expectedIsNamespaced: true,
+		},
+	} {
+		fake, client := newFakeApiServer(t)
+
+		resource, err := jobber.NewGenericK8sResourceFromUnstructured(&unstructured.Unstructured{Object: testCase.object}, client)
+		if err != nil {
+			t.Fatalf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+		}
+
+		if resource.IsNamespaced() != testCase.expectedIsNamespaced {
+			t.Errorf("[%s] expected IsNamespaced() = (%t), got (%t)", testCase.testName, testCase.expectedIsNamespaced, resource.IsNamespaced())
+		}
+
+		if resource.IsNamespaced() {
+			resource.SetNamespace(testCase.namespaceToSet)
+		}
+
+		existingObject, err := resource.Apply(context.Background())
+		if err != nil {
+			t.Fatalf("[%s] expected no error on Apply(), got error = (%s)", testCase.testName, err)
+		}
+
+		if existingObject != nil {
+			t.Errorf("[%s] expected no existing object, got one", testCase.testName)
+		}
+
+		if resource.NamespaceName() != testCase.expectedNamespace {
+			t.Errorf("[%s] expected NamespaceName() = (%s), got (%s)", testCase.testName, testCase.expectedNamespace, resource.NamespaceName())
+		}
+
+		expectedRequests := []string{"GET " + testCase.expectedObjectPath, "PATCH " + testCase.expectedObjectPath}
+		if diff := deep.Equal(fake.recordedRequests(), expectedRequests); diff != nil {
+			t.Errorf("[%s] requests differ from those expected: %v", testCase.testName, diff)
+		}
+
+		var appliedObject map[string]any
+		if err := json.Unmarshal(fake.objects[testCase.expectedObjectPath], &appliedObject); err != nil {
+			t.Errorf("[%s] expected object to be applied at (%s), got error = (%s)", testCase.testName, testCase.expectedObjectPath, err)
+		}
+	}
+}
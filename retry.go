@@ -0,0 +1,152 @@
+package jobber
+
+import (
+	"errors"
+	"os/exec"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// defaultActionRetryBackoff is the delay before the first retry of a Pipeline Action that sets Retries but not
+// Backoff.
+const defaultActionRetryBackoff = time.Second
+
+// maximumActionRetryBackoff caps the delay between attempts, however many retries are configured.
+const maximumActionRetryBackoff = 10 * time.Minute
+
+// knownApiErrorReasons are the API error reasons that a RetryOn.ApiErrors entry may name.  Whether a reason is worth
+// retrying is for the entry to decide: some (e.g., ServiceUnavailable) are transient, while others (e.g., NotFound)
+// only clear if something else changes the cluster in the meantime.
+var knownApiErrorReasons = []metav1.StatusReason{
+	metav1.StatusReasonUnauthorized,
+	metav1.StatusReasonForbidden,
+	metav1.StatusReasonNotFound,
+	metav1.StatusReasonAlreadyExists,
+	metav1.StatusReasonConflict,
+	metav1.StatusReasonGone,
+	metav1.StatusReasonInvalid,
+	metav1.StatusReasonServerTimeout,
+	metav1.StatusReasonTimeout,
+	metav1.StatusReasonTooManyRequests,
+	metav1.StatusReasonBadRequest,
+	metav1.StatusReasonMethodNotAllowed,
+	metav1.StatusReasonNotAcceptable,
+	metav1.StatusReasonRequestEntityTooLarge,
+	metav1.StatusReasonUnsupportedMediaType,
+	metav1.StatusReasonInternalError,
+	metav1.StatusReasonExpired,
+	metav1.StatusReasonServiceUnavailable,
+}
+
+func isKnownApiErrorReason(reason string) bool {
+	for _, r := range knownApiErrorReasons {
+		if string(r) == reason {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ActionRetryPolicy determines whether a failed Pipeline Action is run again and how long to wait before doing
+// so.  If both RetryableApiErrorReasons and RetryableExitCodes are empty, every failure is retryable.
+type ActionRetryPolicy struct {
+	Retries                  uint
+	Backoff                  time.Duration
+	RetryableApiErrorReasons []metav1.StatusReason
+	RetryableExitCodes       []int
+}
+
+// NewActionRetryPolicyFromConfiguration returns the retry policy set by a .Test.Pipeline.ActionsInOrder entry, or
+// nil if the entry does not allow retries.
+func NewActionRetryPolicyFromConfiguration(entry *ConfigurationPipelineAction) *ActionRetryPolicy {
+	if entry.Retries == 0 {
+		return nil
+	}
+
+	policy := &ActionRetryPolicy{
+		Retries: entry.Retries,
+		Backoff: entry.Backoff,
+	}
+
+	if entry.RetryOn != nil {
+		for _, reason := range entry.RetryOn.ApiErrors {
+			policy.RetryableApiErrorReasons = append(policy.RetryableApiErrorReasons, metav1.StatusReason(reason))
+		}
+		policy.RetryableExitCodes = entry.RetryOn.ExitCodes
+	}
+
+	return policy
+}
+
+// MaximumNumberOfAttempts returns the number of times the Pipeline Action may be run, including the first.  For a
+// nil policy, that is 1.
+func (policy *ActionRetryPolicy) MaximumNumberOfAttempts() uint {
+	if policy == nil {
+		return 1
+	}
+
+	return policy.Retries + 1
+}
+
+// AllowsRetryAfter returns true if attempt (counting from 1) failed with err and the policy allows another
+// attempt.  Failures are matched against the API status reason of err or, for an executable or a pod-exec command,
+// its exit code.
+func (policy *ActionRetryPolicy) AllowsRetryAfter(attempt uint, err error) bool {
+	if policy == nil || err == nil || attempt > policy.Retries {
+		return false
+	}
+
+	if len(policy.RetryableApiErrorReasons) == 0 && len(policy.RetryableExitCodes) == 0 {
+		return true
+	}
+
+	if reason := apierrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		for _, r := range policy.RetryableApiErrorReasons {
+			if r == reason {
+				return true
+			}
+		}
+	}
+
+	exitCode, hasAnExitCode := exitCodeOf(err)
+	if hasAnExitCode {
+		for _, code := range policy.RetryableExitCodes {
+			if code == exitCode {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// exitCodeOf returns the exit code of the process whose failure err describes, if it does describe one.  The
+// process may be a local executable or a command run in a container.
+func exitCodeOf(err error) (exitCode int, hasAnExitCode bool) {
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return exitError.ExitCode(), true
+	}
+
+	var remoteExitError utilexec.ExitError
+	if errors.As(err, &remoteExitError) {
+		return remoteExitError.ExitStatus(), true
+	}
+
+	return 0, false
+}
+
+// BackoffAfter returns the delay before the attempt that follows attempt (counting from 1).  The delay doubles
+// with each attempt, up to maximumActionRetryBackoff.
+func (policy *ActionRetryPolicy) BackoffAfter(attempt uint) time.Duration {
+	backoff := policy.Backoff
+	for i := uint(1); i < attempt && backoff < maximumActionRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maximumActionRetryBackoff)
+}
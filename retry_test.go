@@ -0,0 +1,102 @@
+package jobber_test
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/blorticus-go/jobber"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+func exitErrorWithCode(t *testing.T, code int) error {
+	err := exec.CommandContext(context.Background(), "sh", "-c", fmt.Sprintf("exit %d", code)).Run()
+	if err == nil {
+		t.Fatalf("expected command to exit with code %d, but it succeeded", code)
+	}
+
+	return fmt.Errorf("wrapped: %w", err)
+}
+
+func TestActionRetryPolicy(t *testing.T) {
+	podsResource := schema.GroupResource{Resource: "pods"}
+	serverTimeout := fmt.Errorf("failed to create resource: %w", apierrors.NewServerTimeout(podsResource, "create", 1))
+	conflict := apierrors.NewConflict(podsResource, "sleeper", fmt.Errorf("namespace is terminating"))
+
+	for _, testCase := range []struct {
+		testName      string
+		entry         *jobber.ConfigurationPipelineAction
+		attempt       uint
+		err           error
+		expectARetry  bool
+		expectedDelay time.Duration
+	}{
+		{
+			testName:     "any failure is retryable when RetryOn is not set",
+			entry:        &jobber.ConfigurationPipelineAction{Retries: 2, Backoff: time.Second},
+			attempt:      1,
+			err:          fmt.Errorf("template did not expand"),
+			expectARetry: true,
+		},
+		{
+			testName: "retries are exhausted",
+			entry:    &jobber.ConfigurationPipelineAction{Retries: 2, Backoff: time.Second},
+			attempt:  3,
+			err:      fmt.Errorf("template did not expand"),
+		},
+		{
+			testName:      "wrapped API error reason matches",
+			entry:         &jobber.ConfigurationPipelineAction{Retries: 3, Backoff: time.Second, RetryOn: &jobber.ConfigurationRetryOn{ApiErrors: []string{"ServerTimeout"}}},
+			attempt:       3,
+			err:           serverTimeout,
+			expectARetry:  true,
+			expectedDelay: 4 * time.Second,
+		},
+		{
+			testName: "API error reason does not match",
+			entry:    &jobber.ConfigurationPipelineAction{Retries: 3, Backoff: time.Second, RetryOn: &jobber.ConfigurationRetryOn{ApiErrors: []string{"ServerTimeout"}}},
+			attempt:  1,
+			err:      conflict,
+		},
+		{
+			testName:     "exit code matches",
+			entry:        &jobber.ConfigurationPipelineAction{Retries: 1, Backoff: time.Second, RetryOn: &jobber.ConfigurationRetryOn{ExitCodes: []int{75}}},
+			attempt:      1,
+			err:          exitErrorWithCode(t, 75),
+			expectARetry: true,
+		},
+		{
+			testName: "exit code does not match",
+			entry:    &jobber.ConfigurationPipelineAction{Retries: 1, Backoff: time.Second, RetryOn: &jobber.ConfigurationRetryOn{ExitCodes: []int{75}}},
+			attempt:  1,
+			err:      exitErrorWithCode(t, 1),
+		},
+		{
+			testName:     "exit code of command in container matches",
+			entry:        &jobber.ConfigurationPipelineAction{Retries: 1, Backoff: time.Second, RetryOn: &jobber.ConfigurationRetryOn{ExitCodes: []int{75}}},
+			attempt:      1,
+			err:          fmt.Errorf("command failed: %w", utilexec.CodeExitError{Err: fmt.Errorf("command terminated with exit code 75"), Code: 75}),
+			expectARetry: true,
+		},
+	} {
+		policy := jobber.NewActionRetryPolicyFromConfiguration(testCase.entry)
+
+		if retries := policy.AllowsRetryAfter(testCase.attempt, testCase.err); retries != testCase.expectARetry {
+			t.Errorf("[%s] expected AllowsRetryAfter() = %t, got %t", testCase.testName, testCase.expectARetry, retries)
+		}
+
+		if testCase.expectedDelay != 0 {
+			if delay := policy.BackoffAfter(testCase.attempt); delay != testCase.expectedDelay {
+				t.Errorf("[%s] expected BackoffAfter() = %s, got %s", testCase.testName, testCase.expectedDelay, delay)
+			}
+		}
+	}
+
+	if policy := jobber.NewActionRetryPolicyFromConfiguration(&jobber.ConfigurationPipelineAction{Action: "resources/a.yaml"}); policy != nil {
+		t.Errorf("expected no policy for an entry without Retries, got (%v)", policy)
+	}
+}
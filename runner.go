@@ -2,37 +2,77 @@ package jobber
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type Runner struct {
-	client          *Client
-	config          *Configuration
-	resourceTracker *CreatedResourceTracker
+	client               *Client
+	config               *Configuration
+	resumeFromCheckpoint *TestCheckpoint
+	selection            *TestSelection
+	followJobLogs        bool
+	transformsInDryRun   bool
 }
 
 func NewRunner(config *Configuration, client *Client) *Runner {
 	return &Runner{
-		client:          client,
-		config:          config,
-		resourceTracker: NewCreatedResourceTracker(),
+		client: client,
+		config: config,
 	}
 }
 
-func (runner *Runner) createDefaultNamespace(pipelineVariables *PipelineVariables) (*corev1.Namespace, error) {
+// ResumingFrom causes the next RunTest() to skip the Test Cases that checkpoint records as having succeeded.
+func (runner *Runner) ResumingFrom(checkpoint *TestCheckpoint) *Runner {
+	runner.resumeFromCheckpoint = checkpoint
+	return runner
+}
+
+// WithSelection limits RunTest() to the Test Cases that selection selects.
+func (runner *Runner) WithSelection(selection *TestSelection) *Runner {
+	runner.selection = selection
+	return runner
+}
+
+// FollowingJobLogs causes RunTest() to report the Pod logs of every Job, as if every resources action set Follow.
+func (runner *Runner) FollowingJobLogs() *Runner {
+	runner.followJobLogs = true
+	return runner
+}
+
+// RunningValuesTransformsInDryRun causes DryRunTest() to run values-transforms rather than skip them.
+func (runner *Runner) RunningValuesTransformsInDryRun() *Runner {
+	runner.transformsInDryRun = true
+	return runner
+}
+
+// executionEnvironmentFor returns the environment for a Pipeline whose assets are written under paths.
+func (runner *Runner) executionEnvironmentFor(paths *TestCaseDirectoryPaths) *PipelineExecutionEnvironment {
+	return &PipelineExecutionEnvironment{
+		EnvironmentalVariables:       runner.config.Test.Pipeline.ExecutionEnvironment,
+		FollowJobLogs:                runner.followJobLogs,
+		RetrievedAssetsDirectoryPath: paths.RetrievedAssets,
+	}
+}
+
+func (runner *Runner) createDefaultNamespace(ctx context.Context, pipelineVariables *PipelineVariables, resourceTracker *CreatedResourceTracker) (*corev1.Namespace, error) {
 	action, err := PipelineActionFromStringDescriptor("resources/default-namespace.yaml", runner.config.Test.Pipeline.ActionDefinitionsRootDirectory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create action for resources/default-namespace.yaml: %s", err)
 	}
 
 	actionEventChannel := make(chan *ActionEvent)
-	go action.Run(pipelineVariables, &PipelineExecutionEnvironment{EnvironmentalVariables: runner.config.Test.Pipeline.ExecutionEnvironment}, runner.client, actionEventChannel)
+	go action.Run(ctx, pipelineVariables, &PipelineExecutionEnvironment{EnvironmentalVariables: runner.config.Test.Pipeline.ExecutionEnvironment}, runner.client, actionEventChannel)
 
 	var createdResource *GenericK8sResource
 
@@ -41,7 +81,7 @@ EventLoop:
 		event := <-actionEventChannel
 		switch event.Type {
 		case AnErrorOccurred:
-			return nil, fmt.Errorf("error on attempt to create default namespace from resources/default-namespace.yaml: %s", err)
+			return nil, fmt.Errorf("error on attempt to create default namespace from resources/default-namespace.yaml: %s", event.Error)
 		case ResourceCreated:
 			createdResource = event.AffectedResource
 		case ActionCompletedSuccessfully:
@@ -56,164 +96,792 @@ EventLoop:
 
 	namespaceName := nsObject.Name
 
-	runner.resourceTracker.AddCreatedResource(&DeletableK8sResource{
+	resourceTracker.AddCreatedResource(&DeletableK8sResource{
 		information: &K8sResourceInformation{
 			Kind:          "Namespace",
 			Name:          namespaceName,
 			NamespaceName: "",
 		},
 		deletionMethod: func(object any) error {
-			return runner.client.DeleteNamespace(namespaceName)
+			// Deletion must proceed even if the test context has been cancelled
+			return runner.client.DeleteNamespace(context.Background(), namespaceName)
 		},
 	})
 
 	return nsObject, nil
 }
 
-func (runner *Runner) RunTest(eventChannel chan<- *Event) {
+// RunTest runs the Test, sending its events to eventChannel, which is closed when RunTest returns.  If ctx is
+// cancelled, the running Pipeline Actions are abandoned and the final event is TestingCancelled.
+func (runner *Runner) RunTest(ctx context.Context, eventChannel chan<- *Event) {
+	defer close(eventChannel)
+
 	eventHandler := &eventHandler{eventChannel}
 	assetsDirectoryManager := NewContextualAssetsDirectoryManager()
 
-	outcome := assetsDirectoryManager.CreateTestAssetsRootDirectory()
-	if eventHandler.explainAssetCreationOutcome(outcome, nil, nil); outcome.DirectoryCreationFailureError != nil {
-		return
+	var checkpoint *TestCheckpoint
+	var err error
+
+	if runner.resumeFromCheckpoint != nil {
+		checkpoint = runner.resumeFromCheckpoint
+		if err := checkpoint.VerifyThatItIsFor(runner.config, runner.selection); err != nil {
+			eventHandler.sayThatCheckpointDoesNotMatchTest(checkpoint.FilePath(), err)
+			return
+		}
+
+		if err := assetsDirectoryManager.AdoptExistingTestAssetsRootDirectory(checkpoint.AssetsRootDirectoryPath); err != nil {
+			eventHandler.sayThatAssetDirectoryCreationFailed(checkpoint.AssetsRootDirectoryPath, fmt.Errorf("cannot reuse assets directory from checkpoint: %s", err), nil, nil)
+			return
+		}
+
+		eventHandler.sayThatTestIsResumingFromCheckpoint(checkpoint.FilePath())
+	} else {
+		outcome := assetsDirectoryManager.CreateTestAssetsRootDirectory()
+		if eventHandler.explainAssetCreationOutcome(outcome, nil, nil); outcome.DirectoryCreationFailureError != nil {
+			return
+		}
+
+		checkpoint, err = NewTestCheckpoint(assetsDirectoryManager.TestRootAssetDirectoryPath(), runner.config, runner.selection)
+		if err != nil {
+			eventHandler.sayThatCheckpointFileUpdateFailed(CheckpointFilePathFor(assetsDirectoryManager.TestRootAssetDirectoryPath()), err)
+			return
+		}
+
+		if err := checkpoint.WriteToFile(); err != nil {
+			eventHandler.sayThatCheckpointFileUpdateFailed(checkpoint.FilePath(), err)
+		} else {
+			eventHandler.sayThatCheckpointFileWasCreated(checkpoint.FilePath())
+		}
+	}
+
+	if runner.selection != nil {
+		selectionFilePath, err := assetsDirectoryManager.WriteFileInTestRootDirectory(testSelectionFileName, []byte(runner.selection.String()))
+		if err != nil {
+			eventHandler.sayThatSelectionFileCreationFailed(selectionFilePath, err)
+		}
+
+		eventHandler.sayThatSelectionWasApplied(runner.selection, selectionFilePath)
 	}
 
 	templateExpansionVariables := NewEmptyPipelineVariables(runner.client).WithGlobalValues(runner.config.Test.GlobalValues)
 
-	testCasePipeline, err := NewPipelineFromStringDescriptors(runner.config.Test.Pipeline.ActionsInOrder, runner.config.Test.Pipeline.ActionDefinitionsRootDirectory)
+	testCasePipeline, err := NewPipelineFromConfiguration(runner.config.Test)
+	if err != nil {
+		eventHandler.sayThatPipelineDefinitionIsInvalid(err)
+		return
+	}
+
+	maximumConcurrentTestCases := runner.config.Test.Concurrency
+	if maximumConcurrentTestCases == 0 {
+		maximumConcurrentTestCases = 1
+	}
+
+	concurrencyLimiter := make(chan struct{}, maximumConcurrentTestCases)
+	runningTestCases := new(sync.WaitGroup)
+	aTestCaseHasFailed := new(atomic.Bool)
+	aSetupOrTeardownHasFailed := new(atomic.Bool)
+	leftBehindResources := new(resourceInformationList)
+	outcomeMatrix := newTestOutcomeMatrix(runner.config, checkpoint)
+
+	testScope, err := runner.newSetupAndTeardownScope(runner.config.Test.Pipeline.TestSetup, runner.config.Test.Pipeline.TestTeardown, templateExpansionVariables.RescopedToUnitNamed(""), nil)
 	if err != nil {
 		eventHandler.sayThatPipelineDefinitionIsInvalid(err)
 		return
 	}
 
+	// tearDownUnit runs once the Test Cases of a Unit have finished or will not run
+	tearDownUnit := func(unitScope *setupAndTeardownScope) {
+		leftBehind, err := runner.runTeardown(ctx, unitScope, outcomeMatrix.NumberOfFailuresFor(unitScope.testUnit.Name) == 0, eventHandler)
+		leftBehindResources.add(leftBehind)
+		if err != nil {
+			aSetupOrTeardownHasFailed.Store(true)
+		}
+	}
+
+	if err := runner.runSetup(ctx, testScope, assetsDirectoryManager, eventHandler); err != nil {
+		aSetupOrTeardownHasFailed.Store(true)
+	}
+
+UnitLoop:
 	for _, testUnit := range runner.config.Test.Units {
-		eventHandler.sayThatUnitStarted(testUnit)
+		if aSetupOrTeardownHasFailed.Load() && (testScope.setupFailed || !runner.config.Test.ContinueOnFailure) {
+			break
+		}
 
-		outcome := assetsDirectoryManager.CreateTestUnitDirectory(testUnit)
-		if eventHandler.explainAssetCreationOutcome(outcome, testUnit, nil); outcome.DirectoryCreationFailureError != nil {
-			return
+		testCasesToRun := runner.testCasesToRunFor(testUnit, checkpoint, eventHandler)
+		if len(testCasesToRun) == 0 {
+			continue
 		}
 
 		templateExpansionVariables := templateExpansionVariables.RescopedToUnitNamed(testUnit.Name).WithUnitValues(testUnit.Values)
+		unitProgress := newTestUnitProgress(len(testCasesToRun))
 
-		for _, testCase := range runner.config.Test.Cases {
-			eventHandler.sayThatCaseStarted(testUnit, testCase)
+		unitScope, err := runner.newSetupAndTeardownScope(runner.config.Test.Pipeline.UnitSetup, runner.config.Test.Pipeline.UnitTeardown, templateExpansionVariables.DeepCopy(), testUnit)
+		if err != nil {
+			eventHandler.sayThatPipelineDefinitionIsInvalid(err)
+			aSetupOrTeardownHasFailed.Store(true)
+			break
+		}
+
+		numberOfTestCasesStarted := 0
+		stopStartingTestUnits := false
 
-			outcome := assetsDirectoryManager.CreateTestCaseDirectories(testUnit, testCase)
-			if eventHandler.explainAssetCreationOutcome(outcome, testUnit, testCase); outcome.DirectoryCreationFailureError != nil {
-				return
+		for testCaseIndex, testCase := range testCasesToRun {
+			select {
+			case concurrencyLimiter <- struct{}{}:
+			case <-ctx.Done():
+				stopStartingTestUnits = true
 			}
 
-			nsObject, err := runner.createDefaultNamespace(templateExpansionVariables)
-			if eventHandler.explainAttemptToCreateDefaultNamespace(nsObject, EventContextFor(testUnit, testCase), err); err != nil {
-				return
+			if stopStartingTestUnits {
+				break
 			}
 
-			templateExpansionVariables := templateExpansionVariables.
-				RescopedToCaseNamed(testCase.Name).
-				WithCaseValues(testCase.Values).
-				AndTestCaseRetrievedAssetsDirectoryAt(assetsDirectoryManager.TestCaseAssetsDirectoryPathsFor(testUnit, testCase).RetrievedAssets).
-				AndUsingDefaultNamespaceNamed(nsObject.Name)
+			if (aTestCaseHasFailed.Load() && !runner.config.Test.ContinueOnFailure) || ctx.Err() != nil {
+				<-concurrencyLimiter
+				stopStartingTestUnits = true
+				break
+			}
 
-			for action := testCasePipeline.Restart(); action != nil; action = testCasePipeline.NextAction() {
-				actionEventChannel := make(chan *ActionEvent)
+			// A Unit starts only once one of its Test Cases can run
+			if testCaseIndex == 0 {
+				eventHandler.sayThatUnitStarted(testUnit)
 
-				go action.Run(templateExpansionVariables, &PipelineExecutionEnvironment{EnvironmentalVariables: runner.config.Test.Pipeline.ExecutionEnvironment}, runner.client, actionEventChannel)
+				outcome := assetsDirectoryManager.CreateTestUnitDirectory(testUnit)
+				if eventHandler.explainAssetCreationOutcome(outcome, testUnit, nil); outcome.DirectoryCreationFailureError != nil {
+					<-concurrencyLimiter
+					aTestCaseHasFailed.Store(true)
+					break UnitLoop
+				}
 
-				if err := runner.handleActionEvents(action, actionEventChannel, eventHandler, assetsDirectoryManager, testUnit, testCase); err != nil {
-					return
+				if err := runner.runSetup(ctx, unitScope, assetsDirectoryManager, eventHandler); err != nil {
+					<-concurrencyLimiter
+					aSetupOrTeardownHasFailed.Store(true)
+					stopStartingTestUnits = !runner.config.Test.ContinueOnFailure
+					break
 				}
 			}
 
-			for _, attemptDetails := range runner.resourceTracker.AttemptToDeleteAllAsYetUndeletedResources() {
-				if attemptDetails.Error != nil {
-					eventHandler.sayThatResourceDeletionFailed(attemptDetails.Resource.information, attemptDetails.Error, testUnit, testCase)
-					return
+			numberOfTestCasesStarted++
+			runningTestCases.Add(1)
+			go func(testUnit *TestUnit, testCase *TestCase, unitVariables *PipelineVariables, unitProgress *testUnitProgress, unitScope *setupAndTeardownScope) {
+				defer func() {
+					<-concurrencyLimiter
+					runningTestCases.Done()
+				}()
+
+				leftBehind, err := runner.runTestCase(ctx, testCasePipeline.Copy(), unitVariables, eventHandler, assetsDirectoryManager, testUnit, testCase)
+				leftBehindResources.add(leftBehind)
+
+				if ctx.Err() == nil {
+					runner.recordTestCaseOutcomeInCheckpoint(checkpoint, err, eventHandler, assetsDirectoryManager, testUnit, testCase)
+					outcomeMatrix.record(testUnit, testCase, err)
+
+					if err != nil {
+						assetsDirectoryManager.WriteFailureMarkerFor(testUnit, testCase, err)
+						if testCase.AllowFailure {
+							eventHandler.sayThatCaseFailedButFailureIsAllowed(testUnit, testCase, err)
+						} else {
+							aTestCaseHasFailed.Store(true)
+						}
+					}
 				}
 
-				eventHandler.sayThatResourceDeletionSucceeded(attemptDetails.Resource.information, testUnit, testCase)
+				if allTestCasesHaveFinished, allTestCasesRan := unitProgress.recordThatATestCaseFinished(); allTestCasesHaveFinished {
+					tearDownUnit(unitScope)
+
+					if ctx.Err() == nil && allTestCasesRan {
+						if outcomeMatrix.NumberOfFailuresFor(testUnit.Name) == 0 {
+							eventHandler.sayThatUnitCompletedSuccessfully(testUnit)
+						} else {
+							eventHandler.sayThatUnitCompletedWithFailures(testUnit, outcomeMatrix.NumberOfFailuresFor(testUnit.Name))
+						}
+					}
+				}
+			}(testUnit, testCase, templateExpansionVariables, unitProgress, unitScope)
+		}
+
+		if numberOfTestCasesStarted < len(testCasesToRun) {
+			if unitProgress.recordThatTestCasesWillNotRun(len(testCasesToRun) - numberOfTestCasesStarted) {
+				tearDownUnit(unitScope)
+			}
+		}
+
+		if stopStartingTestUnits {
+			break
+		}
+	}
+
+	runningTestCases.Wait()
+
+	leftBehind, err := runner.runTeardown(ctx, testScope, !aTestCaseHasFailed.Load() && !aSetupOrTeardownHasFailed.Load(), eventHandler)
+	leftBehindResources.add(leftBehind)
+	if err != nil {
+		aSetupOrTeardownHasFailed.Store(true)
+	}
+
+	cleanupPolicy := runner.config.Test.Cleanup.Policy
+
+	if ctx.Err() != nil {
+		// The assets and checkpoint are kept only if a completed Test Case makes the Test worth resuming
+		retainedAssetsDirectoryPath := assetsDirectoryManager.TestRootAssetDirectoryPath()
+
+		switch {
+		case cleanupPolicy == CleanupNever || (cleanupPolicy == CleanupOnSuccess && checkpoint.HasRecordedOutcomes()):
+			eventHandler.sayThatCheckpointFileWasRetained(checkpoint.FilePath())
+		default:
+			if runner.removeAssetsDirectory(assetsDirectoryManager, eventHandler) {
+				retainedAssetsDirectoryPath = ""
 			}
+			runner.removeCheckpointFile(checkpoint, eventHandler)
+		}
+
+		eventHandler.sayThatTestingWasCancelled(ctx.Err(), leftBehindResources.list(), retainedAssetsDirectoryPath)
+		return
+	}
+
+	eventHandler.sayThatTestOutcomesAre(outcomeMatrix)
 
-			eventHandler.sayThatCaseCompletedSuccessfully(testUnit, testCase)
+	if aTestCaseHasFailed.Load() || aSetupOrTeardownHasFailed.Load() {
+		retainedAssetsDirectoryPath := assetsDirectoryManager.TestRootAssetDirectoryPath()
+
+		// The archive is complete when continuing on failure, and is the only record once the assets are removed
+		if runner.config.Test.ContinueOnFailure || cleanupPolicy == CleanupAlways {
+			runner.generateArchive(assetsDirectoryManager, eventHandler)
+		}
+
+		if cleanupPolicy == CleanupAlways {
+			if runner.removeAssetsDirectory(assetsDirectoryManager, eventHandler) {
+				retainedAssetsDirectoryPath = ""
+			}
+			runner.removeCheckpointFile(checkpoint, eventHandler)
+		} else {
+			eventHandler.sayThatCheckpointFileWasRetained(checkpoint.FilePath())
 		}
 
-		eventHandler.sayThatUnitCompletedSuccessfully(testUnit)
+		eventHandler.sayThatTestingFailed(leftBehindResources.list(), retainedAssetsDirectoryPath)
+		return
+	}
+
+	if !runner.generateArchive(assetsDirectoryManager, eventHandler) {
+		eventHandler.sayThatTestingFailed(leftBehindResources.list(), assetsDirectoryManager.TestRootAssetDirectoryPath())
+		return
 	}
 
+	retainedAssetsDirectoryPath := assetsDirectoryManager.TestRootAssetDirectoryPath()
+
+	if cleanupPolicy != CleanupNever {
+		if !runner.removeAssetsDirectory(assetsDirectoryManager, eventHandler) {
+			eventHandler.sayThatTestingFailed(leftBehindResources.list(), retainedAssetsDirectoryPath)
+			return
+		}
+
+		retainedAssetsDirectoryPath = ""
+	}
+
+	runner.removeCheckpointFile(checkpoint, eventHandler)
+
+	eventHandler.sayThatTestingCompletedSuccessfully(leftBehindResources.list(), retainedAssetsDirectoryPath)
+}
+
+func (runner *Runner) generateArchive(assetsDirectoryManager *ContextualAssetsDirectoryManager, eventHandler *eventHandler) (archiveWasCreated bool) {
 	if err := assetsDirectoryManager.GenerateArchiveFileAt(runner.config.Test.AssetArchive.FilePath); err != nil {
 		eventHandler.sayThatArchiveCreationFailed(runner.config.Test.AssetArchive.FilePath, assetsDirectoryManager.TestRootAssetDirectoryPath(), err)
-		return
+		return false
 	}
 
 	eventHandler.sayThatArchiveCreationSucceeded(runner.config.Test.AssetArchive.FilePath)
+	return true
+}
 
+func (runner *Runner) removeAssetsDirectory(assetsDirectoryManager *ContextualAssetsDirectoryManager, eventHandler *eventHandler) (directoryWasRemoved bool) {
 	if err := assetsDirectoryManager.RemoveAssetsDirectory(); err != nil {
 		eventHandler.sayThatAssetDirectoryDeletionFailed(assetsDirectoryManager.TestRootAssetDirectoryPath(), err)
-		return
+		return false
 	}
 
 	eventHandler.sayThatAssetDirectoryDeletionWasSuccessful(assetsDirectoryManager.TestRootAssetDirectoryPath())
+	return true
+}
+
+// testCasesToRunFor returns the selected Test Cases of testUnit that checkpoint does not record as having succeeded.
+func (runner *Runner) testCasesToRunFor(testUnit *TestUnit, checkpoint *TestCheckpoint, eventHandler *eventHandler) []*TestCase {
+	testCasesToRun := make([]*TestCase, 0, len(runner.config.Test.Cases))
+
+	for _, testCase := range runner.config.Test.Cases {
+		if runner.selection != nil && !runner.selection.Selects(testUnit, testCase) {
+			continue
+		}
+
+		if checkpoint != nil && checkpoint.TestCaseSucceeded(testUnit.Name, testCase.Name) {
+			eventHandler.sayThatCaseWasAlreadyCompleted(testUnit, testCase)
+			continue
+		}
 
-	eventHandler.sayThatTestingCompletedSuccessfully()
+		testCasesToRun = append(testCasesToRun, testCase)
+	}
+
+	return testCasesToRun
+}
+
+func (runner *Runner) recordTestCaseOutcomeInCheckpoint(checkpoint *TestCheckpoint, testCaseError error, eventHandler *eventHandler, assetsDirectoryManager *ContextualAssetsDirectoryManager, testUnit *TestUnit, testCase *TestCase) {
+	outcome := TestCaseSucceeded
+	if testCaseError != nil {
+		outcome = TestCaseFailed
+	}
+
+	testCaseAssetsDirectoryPath := ""
+	if paths := assetsDirectoryManager.TestCaseAssetsDirectoryPathsFor(testUnit, testCase); paths != nil {
+		testCaseAssetsDirectoryPath = paths.Root
+	}
+
+	if err := checkpoint.RecordOutcome(testUnit, testCase, testCaseAssetsDirectoryPath, outcome); err != nil {
+		eventHandler.sayThatCheckpointFileUpdateFailed(checkpoint.FilePath(), err)
+	}
 }
 
-func (runner *Runner) handleActionEvents(action *PipelineAction, actionEventChannel <-chan *ActionEvent, eventHandler *eventHandler, assetsDirectoryManager *ContextualAssetsDirectoryManager, testUnit *TestUnit, testCase *TestCase) error {
+func (runner *Runner) removeCheckpointFile(checkpoint *TestCheckpoint, eventHandler *eventHandler) {
+	if err := checkpoint.RemoveFile(); err != nil {
+		eventHandler.sayThatCheckpointFileRemovalFailed(checkpoint.FilePath(), err)
+	} else {
+		eventHandler.sayThatCheckpointFileWasRemoved(checkpoint.FilePath())
+	}
+}
+
+// runTestCase runs each iteration of testCase in its own default Namespace, stopping at the first failure, and
+// returns the resources left behind.
+func (runner *Runner) runTestCase(ctx context.Context, testCasePipeline *Pipeline, unitVariables *PipelineVariables, eventHandler *eventHandler, assetsDirectoryManager *ContextualAssetsDirectoryManager, testUnit *TestUnit, testCase *TestCase) ([]*K8sResourceInformation, error) {
+	eventHandler.sayThatCaseStarted(testUnit, testCase)
+
+	numberOfIterations := runner.config.Test.IterationsFor(testCase)
+
+	outcome := assetsDirectoryManager.CreateTestCaseDirectories(testUnit, testCase, numberOfIterations)
+	if eventHandler.explainAssetCreationOutcome(outcome, testUnit, testCase); outcome.DirectoryCreationFailureError != nil {
+		return nil, outcome.DirectoryCreationFailureError
+	}
+
+	testCasePaths := assetsDirectoryManager.TestCaseAssetsDirectoryPathsFor(testUnit, testCase)
+	leftBehind := make([]*K8sResourceInformation, 0)
+
+	for iteration := uint(1); iteration <= numberOfIterations; iteration++ {
+		if numberOfIterations > 1 {
+			eventHandler.sayThatIterationStarted(iteration, numberOfIterations, testUnit, testCase)
+		}
+
+		resourceTracker := NewCreatedResourceTracker()
+
+		err := runner.runPipelineForTestCase(ctx, testCasePipeline, unitVariables, iteration, resourceTracker, eventHandler, testCasePaths.ForIteration(iteration), testUnit, testCase)
+
+		runner.capturePodLogs(ctx, resourceTracker, testCasePaths.ForIteration(iteration), eventHandler, testUnit, testCase)
+
+		deletionAttempts := resourceTracker.AttemptToDeleteResourcesAccordingTo(runner.config.Test.Cleanup.Policy, err == nil, ctx.Err() != nil)
+		if deletionErr := runner.reportResourceDeletionAttempts(deletionAttempts, eventHandler, testUnit, testCase); deletionErr != nil && err == nil {
+			err = deletionErr
+		}
+
+		leftBehind = append(leftBehind, resourceTracker.UndeletedResources()...)
+
+		if err != nil {
+			if numberOfIterations > 1 {
+				err = fmt.Errorf("iteration %d of %d: %w", iteration, numberOfIterations, err)
+			}
+			return leftBehind, err
+		}
+
+		if numberOfIterations > 1 {
+			eventHandler.sayThatIterationCompletedSuccessfully(iteration, numberOfIterations, testUnit, testCase)
+		}
+	}
+
+	eventHandler.sayThatCaseCompletedSuccessfully(testUnit, testCase)
+
+	return leftBehind, nil
+}
+
+// runPipelineForTestCase creates the default Namespace for an iteration of a Test Case, then runs its Pipeline.
+func (runner *Runner) runPipelineForTestCase(ctx context.Context, testCasePipeline *Pipeline, unitVariables *PipelineVariables, iteration uint, resourceTracker *CreatedResourceTracker, eventHandler *eventHandler, iterationPaths *TestCaseDirectoryPaths, testUnit *TestUnit, testCase *TestCase) error {
+	templateExpansionVariables := unitVariables.
+		RescopedToCaseNamed(testCase.Name).
+		WithCaseValues(testCase.Values).
+		ForIteration(iteration).
+		AndTestCaseRetrievedAssetsDirectoryAt(iterationPaths.RetrievedAssets)
+
+	nsObject, err := runner.createDefaultNamespace(ctx, templateExpansionVariables, resourceTracker)
+	if eventHandler.explainAttemptToCreateDefaultNamespace(nsObject, EventContextFor(testUnit, testCase), err); err != nil {
+		return err
+	}
+
+	templateExpansionVariables.AndUsingDefaultNamespaceNamed(nsObject.Name)
+
+	executionEnvironment := runner.executionEnvironmentFor(iterationPaths)
+
+	if failures := runner.runPipeline(ctx, testCasePipeline, false, templateExpansionVariables, executionEnvironment, resourceTracker, eventHandler, iterationPaths, testUnit, testCase); len(failures) > 0 {
+		return failures[0].err
+	}
+
+	return nil
+}
+
+// pipelineActionFailure is a Pipeline Action that failed, and the error with which it failed.
+type pipelineActionFailure struct {
+	action *PipelineAction
+	err    error
+}
+
+// runPipeline runs the actions of pipeline in order or, for a dependency graph, as their dependencies complete.  The
+// failures are returned in the order in which they happened.
+func (runner *Runner) runPipeline(ctx context.Context, pipeline *Pipeline, continuePastFailures bool, templateExpansionVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment, resourceTracker *CreatedResourceTracker, eventHandler *eventHandler, testCasePaths *TestCaseDirectoryPaths, testUnit *TestUnit, testCase *TestCase) []*pipelineActionFailure {
+	failures := make([]*pipelineActionFailure, 0)
+
+	if !pipeline.IsADependencyGraph() {
+		for action := pipeline.Restart(); action != nil; action = pipeline.NextAction() {
+			if ctx.Err() != nil {
+				return append(failures, &pipelineActionFailure{action, ctx.Err()})
+			}
+
+			if err := runner.runPipelineAction(ctx, action, templateExpansionVariables, executionEnvironment, resourceTracker, eventHandler, testCasePaths, testUnit, testCase); err != nil {
+				failures = append(failures, &pipelineActionFailure{action, err})
+				if !continuePastFailures {
+					return failures
+				}
+			}
+		}
+
+		return failures
+	}
+
+	numberOfUnfinishedDependencies := make([]int, len(pipeline.actions))
+	dependents := make([][]int, len(pipeline.actions))
+	for actionIndex, dependencyIndexes := range pipeline.dependencies {
+		numberOfUnfinishedDependencies[actionIndex] = len(dependencyIndexes)
+		for _, dependencyIndex := range dependencyIndexes {
+			dependents[dependencyIndex] = append(dependents[dependencyIndex], actionIndex)
+		}
+	}
+
+	graphCtx, cancelGraph := context.WithCancel(ctx)
+	defer cancelGraph()
+
+	valuesLock := new(sync.RWMutex)
+	completedActionIndexes := make(chan int)
+	actionErrors := make([]error, len(pipeline.actions))
+	numberOfRunningActions := 0
+	startingHasStopped := false
+
+	startActionAt := func(actionIndex int) {
+		action := pipeline.actions[actionIndex]
+
+		if startingHasStopped {
+			return
+		}
+
+		if ctx.Err() != nil {
+			failures = append(failures, &pipelineActionFailure{action, ctx.Err()})
+			startingHasStopped = true
+			return
+		}
+
+		numberOfRunningActions++
+		go func() {
+			if action.Type == ValuesTransform {
+				valuesLock.Lock()
+				defer valuesLock.Unlock()
+			} else {
+				valuesLock.RLock()
+				defer valuesLock.RUnlock()
+			}
+
+			actionErrors[actionIndex] = runner.runPipelineAction(graphCtx, action, templateExpansionVariables, executionEnvironment, resourceTracker, eventHandler, testCasePaths, testUnit, testCase)
+			completedActionIndexes <- actionIndex
+		}()
+	}
+
+	for actionIndex := range pipeline.actions {
+		if numberOfUnfinishedDependencies[actionIndex] == 0 {
+			startActionAt(actionIndex)
+		}
+	}
+
+	for numberOfRunningActions > 0 {
+		actionIndex := <-completedActionIndexes
+		numberOfRunningActions--
+
+		if err := actionErrors[actionIndex]; err != nil {
+			failures = append(failures, &pipelineActionFailure{pipeline.actions[actionIndex], err})
+			if !continuePastFailures {
+				startingHasStopped = true
+				cancelGraph()
+			}
+			continue
+		}
+
+		for _, dependentIndex := range dependents[actionIndex] {
+			if numberOfUnfinishedDependencies[dependentIndex]--; numberOfUnfinishedDependencies[dependentIndex] == 0 {
+				startActionAt(dependentIndex)
+			}
+		}
+	}
+
+	return failures
+}
+
+// runPipelineAction runs action, or each member of a parallel group at the same time, returning the first error.
+func (runner *Runner) runPipelineAction(ctx context.Context, action *PipelineAction, templateExpansionVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment, resourceTracker *CreatedResourceTracker, eventHandler *eventHandler, testCasePaths *TestCaseDirectoryPaths, testUnit *TestUnit, testCase *TestCase) error {
+	if action.Type != ParallelGroup {
+		return runner.runActionWithRetries(ctx, action, templateExpansionVariables, executionEnvironment, resourceTracker, eventHandler, testCasePaths, testUnit, testCase)
+	}
+
+	groupCtx, cancelGroup := context.WithCancel(ctx)
+	defer cancelGroup()
+
+	runningMembers := new(sync.WaitGroup)
+	recordFirstFailure := new(sync.Once)
+	var firstFailure error
+
+	for _, member := range action.Members {
+		runningMembers.Add(1)
+		go func(member *PipelineAction) {
+			defer runningMembers.Done()
+
+			if err := runner.runActionWithRetries(groupCtx, member, templateExpansionVariables, executionEnvironment, resourceTracker, eventHandler, testCasePaths, testUnit, testCase); err != nil {
+				recordFirstFailure.Do(func() {
+					firstFailure = err
+					cancelGroup()
+				})
+			}
+		}(member)
+	}
+
+	runningMembers.Wait()
+
+	return firstFailure
+}
+
+// runActionWithRetries runs action, cleaning up after each failed attempt and retrying as its retry policy allows.
+func (runner *Runner) runActionWithRetries(ctx context.Context, action *PipelineAction, templateExpansionVariables *PipelineVariables, executionEnvironment *PipelineExecutionEnvironment, resourceTracker *CreatedResourceTracker, eventHandler *eventHandler, testCasePaths *TestCaseDirectoryPaths, testUnit *TestUnit, testCase *TestCase) error {
+	conditionIsSatisfied, conditionExpansion, err := action.Condition.IsSatisfiedBy(templateExpansionVariables)
+	if err != nil {
+		eventHandler.sayThatActionConditionIsInvalid(action.Descriptor, action.Condition.Expression, err, testUnit, testCase)
+		return err
+	}
+
+	if !conditionIsSatisfied {
+		eventHandler.sayThatActionWasSkipped(action.Descriptor, action.Condition.Expression, conditionExpansion, testUnit, testCase)
+		if err := noteSkippedAction(testCasePaths, action.Descriptor, action.Condition, conditionExpansion); err != nil {
+			eventHandler.sayThatSkippedActionCouldNotBeNoted(skippedActionsFilePath(testCasePaths), err, testUnit, testCase)
+		}
+		return nil
+	}
+
+	for attempt := uint(1); ; attempt++ {
+		if action.RetryPolicy != nil {
+			eventHandler.sayThatActionAttemptStarted(action.Descriptor, attempt, action.RetryPolicy.MaximumNumberOfAttempts(), testUnit, testCase)
+		}
+
+		attemptResourceTracker := NewCreatedResourceTracker()
+		actionEventChannel := make(chan *ActionEvent)
+
+		go action.Run(ctx, templateExpansionVariables, executionEnvironment, runner.client, actionEventChannel)
+
+		copiedFiles, err := runner.handleActionEvents(action, attempt, actionEventChannel, attemptResourceTracker, eventHandler, testCasePaths, testUnit, testCase)
+		if err == nil || ctx.Err() != nil || !action.RetryPolicy.AllowsRetryAfter(attempt, err) {
+			resourceTracker.AddResourcesTrackedBy(attemptResourceTracker)
+			return err
+		}
+
+		removeFilesCopiedByFailedAttempt(testCasePaths.RetrievedAssets, copiedFiles, eventHandler, testUnit, testCase)
+
+		runner.captureFailedAttemptPodLogs(ctx, action, attempt, attemptResourceTracker, testCasePaths, eventHandler, testUnit, testCase)
+
+		resourcesCreatedByAttempt := attemptResourceTracker.undeletedRuntimeResources()
+
+		if rollbackErr := runner.deleteTrackedResources(attemptResourceTracker, eventHandler, testUnit, testCase); rollbackErr != nil {
+			resourceTracker.AddResourcesTrackedBy(attemptResourceTracker)
+			return err
+		}
+
+		templateExpansionVariables.Runtime.Remove(resourcesCreatedByAttempt...)
+
+		backoff := action.RetryPolicy.BackoffAfter(attempt)
+		eventHandler.sayThatActionAttemptFailed(action.Descriptor, attempt, action.RetryPolicy.MaximumNumberOfAttempts(), err, backoff, testUnit, testCase)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// deleteTrackedResources deletes the tracked resources in reverse dependency order, stopping on the first failure.
+func (runner *Runner) deleteTrackedResources(resourceTracker *CreatedResourceTracker, eventHandler *eventHandler, testUnit *TestUnit, testCase *TestCase) error {
+	return runner.reportResourceDeletionAttempts(resourceTracker.AttemptToDeleteAllAsYetUndeletedResources(), eventHandler, testUnit, testCase)
+}
+
+func (runner *Runner) reportResourceDeletionAttempts(deletionAttempts []*ResourceDeletionAttempt, eventHandler *eventHandler, testUnit *TestUnit, testCase *TestCase) error {
+	for _, attemptDetails := range deletionAttempts {
+		if attemptDetails.Error != nil {
+			if attemptDetails.Resource.restoresAPatch {
+				eventHandler.sayThatResourceRestorationFailed(attemptDetails.Resource.information, attemptDetails.Error, testUnit, testCase)
+			} else {
+				eventHandler.sayThatResourceDeletionFailed(attemptDetails.Resource.information, attemptDetails.Error, testUnit, testCase)
+			}
+			return attemptDetails.Error
+		}
+
+		if attemptDetails.Resource.restoresAPatch {
+			eventHandler.sayThatResourceRestorationSucceeded(attemptDetails.Resource.information, testUnit, testCase)
+			continue
+		}
+
+		eventHandler.sayThatResourceDeletionSucceeded(attemptDetails.Resource.information, testUnit, testCase)
+	}
+
+	return nil
+}
+
+// handleActionEvents handles the events of an attempt to run action, returning its error and the files it copied.
+func (runner *Runner) handleActionEvents(action *PipelineAction, attempt uint, actionEventChannel <-chan *ActionEvent, resourceTracker *CreatedResourceTracker, eventHandler *eventHandler, testCasePaths *TestCaseDirectoryPaths, testUnit *TestUnit, testCase *TestCase) (copiedFiles []string, err error) {
 	for {
 		event := <-actionEventChannel
 		switch event.Type {
 		case TemplateExpanded:
-			writeExpandedTemplateForAction(action, event.ExpandedTemplateBuffer, assetsDirectoryManager.TestCaseAssetsDirectoryPathsFor(testUnit, testCase).ExpandedTemplates)
+			writeExpandedTemplateForAction(action.assetNameForAttempt(attempt), event.ExpandedTemplateBuffer, testCasePaths.ExpandedTemplates)
 		case ResourceCreated:
 			eventHandler.sayThatResourceCreationSucceeded(event.AffectedResource.Information(), func() string { return "" }, testUnit, testCase)
-			runner.resourceTracker.AddCreatedResource(&DeletableK8sResource{
-				information: event.AffectedResource.Information(),
+			resourceTracker.AddCreatedResource(&DeletableK8sResource{
+				information:     event.AffectedResource.Information(),
+				resource:        event.AffectedResource,
+				dependencyDepth: action.dependencyDepth,
 				deletionMethod: func(object any) error {
-					return event.AffectedResource.Delete()
+					// Deletion must proceed even if the test context has been cancelled
+					return event.AffectedResource.Delete(context.Background())
 				},
 			})
 			switch event.AffectedResource.GvkString() {
 			case "v1/Pod":
 			case "batch/v1/Job":
 			}
+		case ResourcePatched:
+			eventHandler.sayThatResourcePatchSucceeded(action.Descriptor, event.PatchedResource.Information(), testUnit, testCase)
+			resourceTracker.AddCreatedResource(&DeletableK8sResource{
+				information:     event.PatchedResource.Information(),
+				restoresAPatch:  true,
+				dependencyDepth: action.dependencyDepth,
+				deletionMethod: func(object any) error {
+					// Restoration must proceed even if the test context has been cancelled
+					return event.PatchedResource.Restore(context.Background())
+				},
+			})
+		case ResourceApplied:
+			eventHandler.sayThatExistingResourceWasApplied(event.AffectedResource.Information(), testUnit, testCase)
+			if runner.config.Test.Cleanup.AppliedResources == AppliedResourcesRevert {
+				resourceTracker.AddCreatedResource(&DeletableK8sResource{
+					information:     event.PatchedResource.Information(),
+					restoresAPatch:  true,
+					dependencyDepth: action.dependencyDepth,
+					deletionMethod: func(object any) error {
+						// Restoration must proceed even if the test context has been cancelled
+						return event.PatchedResource.Restore(context.Background())
+					},
+				})
+			}
+		case ResourceAcceptedInDryRun:
+			eventHandler.sayThatResourceWasAcceptedInDryRun(event.AffectedResource.Information(), testUnit, testCase)
+		case ResourceRejectedInDryRun:
+			eventHandler.sayThatResourceCreationFailed(event.AffectedResource.Information(), func() string { return "" }, event.Error, testUnit, testCase)
+		case ActionSkippedInDryRun:
+			eventHandler.sayThatActionWasSkippedForDryRun(action.Descriptor, testUnit, testCase)
 		case JobCompleted:
 		case PodMovedToRunningState:
+		case ResourceReady:
+			eventHandler.sayThatResourceBecameReady(event.AffectedResource.Information(), testUnit, testCase)
 		case ExecutionSuccessful:
-			attemptToWriteExecutableOutputToFile(assetsDirectoryManager.TestCaseAssetsDirectoryPathsFor(testUnit, testCase).Executables, action.Descriptor, event.StdoutBuffer, event.StderrBuffer)
+			attemptToWriteExecutableOutputToFile(testCasePaths.Executables, action.assetNameForAttempt(attempt), event.StdoutBuffer, event.StderrBuffer)
 			eventHandler.sayThatExecutionSucceeded(action.Descriptor, testUnit, testCase)
+		case FilesCopiedFromPod:
+			copiedFiles = event.CopiedFiles
+			eventHandler.sayThatFilesWereCopiedFromPod(action.Descriptor, event.Description, event.CopiedFiles, testUnit, testCase)
+		case ContainerLogLineRead:
+			eventHandler.sayThatContainerLogLineWasReceived(event.LogLine, testUnit, testCase)
+		case ResourceWaitCompleted:
+			eventHandler.sayThatResourceWaitSucceeded(action.Descriptor, event.Description, testUnit, testCase)
 		case ValuesTransformCompleted:
+			attemptToWriteExecutableOutputToFile(testCasePaths.ValuesTransforms, action.assetNameForAttempt(attempt), event.StdoutBuffer, event.StderrBuffer)
+			eventHandler.sayThatValuesTransformSucceeded(action.Descriptor, event.StdinBuffer, event.StdoutBuffer, event.StderrBuffer, testUnit, testCase)
 		case AnErrorOccurred:
+			if errors.Is(event.Error, ErrorTimeExceeded) {
+				eventHandler.sayThatActionTimedOut(action.Descriptor, event.Error, testUnit, testCase)
+			}
+
 			switch action.Type {
 			case TemplatedResource:
+				var rejectedInDryRun *RejectedInDryRunError
+				if errors.As(event.Error, &rejectedInDryRun) {
+					// Each rejection has been reported already
+					break
+				}
+
 				if event.AffectedResource != nil {
 					eventHandler.sayThatResourceCreationFailed(event.AffectedResource.Information(), func() string { return "" }, event.Error, testUnit, testCase)
 				} else {
 					eventHandler.sayThatResourceTemplateExpansionFailed(action.ActionFullyQualifiedPath, func() string { return "" }, event.Error, testUnit, testCase)
 				}
-			case Executable:
-				attemptToWriteExecutableOutputToFile(assetsDirectoryManager.TestCaseAssetsDirectoryPathsFor(testUnit, testCase).Executables, action.Descriptor, event.StdoutBuffer, event.StderrBuffer)
+			case Executable, PodExec:
+				attemptToWriteExecutableOutputToFile(testCasePaths.Executables, action.assetNameForAttempt(attempt), event.StdoutBuffer, event.StderrBuffer)
 				eventHandler.sayThatExecutionFailed(action.Descriptor, event.Error, testUnit, testCase)
+			case ValuesTransform:
+				attemptToWriteExecutableOutputToFile(testCasePaths.ValuesTransforms, action.assetNameForAttempt(attempt), event.StdoutBuffer, event.StderrBuffer)
+				eventHandler.sayThatValuesTransformFailed(action.Descriptor, event.Error, event.StdinBuffer, event.StdoutBuffer, event.StderrBuffer, testUnit, testCase)
+			case Wait:
+				eventHandler.sayThatResourceWaitFailed(action.Descriptor, event.Error, testUnit, testCase)
+			case Patch:
+				eventHandler.sayThatResourcePatchFailed(action.Descriptor, event.Error, testUnit, testCase)
+			case CopyFromPod:
+				eventHandler.sayThatFileCopyFromPodFailed(action.Descriptor, event.CopiedFiles, event.Error, testUnit, testCase)
 			}
-			return event.Error
+			return event.CopiedFiles, event.Error
 		case ActionCompletedSuccessfully:
-			return nil
+			return copiedFiles, nil
 		}
 	}
 }
 
-func attemptToWriteExecutableOutputToFile(executableAssetsBasePath string, actionDescriptor string, stdoutBuffer *bytes.Buffer, stderrBuffer *bytes.Buffer) {
-	outputFilesBasePath := deriveActionOutputFilesBasePath(executableAssetsBasePath, actionDescriptor)
-	writeReaderToFile(fmt.Sprintf("%s.stdout", outputFilesBasePath), 0640, stdoutBuffer)
-	writeReaderToFile(fmt.Sprintf("%s.stderr", outputFilesBasePath), 0640, stderrBuffer)
+func attemptToWriteExecutableOutputToFile(executableAssetsBasePath string, actionAssetName string, stdoutBuffer *bytes.Buffer, stderrBuffer *bytes.Buffer) {
+	outputFilesBasePath := deriveActionOutputFilesBasePath(executableAssetsBasePath, actionAssetName)
+
+	// The buffers are wrapped in new readers so that they are not drained, allowing the contents to be retrieved later
+	if stdoutBuffer != nil {
+		writeReaderToFile(fmt.Sprintf("%s.stdout", outputFilesBasePath), 0640, bytes.NewReader(stdoutBuffer.Bytes()))
+	}
+	if stderrBuffer != nil {
+		writeReaderToFile(fmt.Sprintf("%s.stderr", outputFilesBasePath), 0640, bytes.NewReader(stderrBuffer.Bytes()))
+	}
 }
 
-func writeExpandedTemplateForAction(action *PipelineAction, expandedTemplateBuffer *bytes.Buffer, assetsDirectoryPath string) {
-	outputFilesBasePath := deriveActionOutputFilesBasePath(assetsDirectoryPath, action.ActionFullyQualifiedPath)
+func writeExpandedTemplateForAction(actionAssetName string, expandedTemplateBuffer *bytes.Buffer, assetsDirectoryPath string) {
+	outputFilesBasePath := deriveActionOutputFilesBasePath(assetsDirectoryPath, actionAssetName)
 	if expandedTemplateBuffer != nil {
 		writeReaderToFile(outputFilesBasePath, 0640, expandedTemplateBuffer)
 	}
 }
 
+// assetNameForAttempt returns the asset name for an attempt to run action (e.g., jmeter-job.attempt-2.yaml).
+func (action *PipelineAction) assetNameForAttempt(attempt uint) string {
+	assetName := path.Base(action.Descriptor)
+	extension := path.Ext(assetName)
+
+	if action.Name != "" {
+		assetName = action.Name + extension
+	}
+
+	if action.RetryPolicy == nil {
+		return assetName
+	}
+
+	return fmt.Sprintf("%s.attempt-%d%s", strings.TrimSuffix(assetName, extension), attempt, extension)
+}
+
 func deriveActionOutputFilesBasePath(depositDirectoryPath string, actionFullyQualifiedName string) string {
 	actionFullyQalifiedNamePathElements := strings.Split(actionFullyQualifiedName, "/")
 	actionBasename := actionFullyQalifiedNamePathElements[len(actionFullyQalifiedNamePathElements)-1]
@@ -240,3 +908,55 @@ func fileExists(filePath string) bool {
 		panic(fmt.Sprintf("os.Stat failed: %s", err))
 	}
 }
+
+// testUnitProgress tracks the Test Cases of a Test Unit that have yet to finish.
+type testUnitProgress struct {
+	mutex                  sync.Mutex
+	numberOfCasesRemaining int
+	someCasesWillNotRun    bool
+}
+
+func newTestUnitProgress(numberOfCasesInUnit int) *testUnitProgress {
+	return &testUnitProgress{
+		numberOfCasesRemaining: numberOfCasesInUnit,
+	}
+}
+
+// recordThatATestCaseFinished reports whether no Test Case of the Unit remains, and whether all of them ran.
+func (p *testUnitProgress) recordThatATestCaseFinished() (allTestCasesHaveFinished bool, allTestCasesRan bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.numberOfCasesRemaining--
+	return p.numberOfCasesRemaining == 0, !p.someCasesWillNotRun
+}
+
+// recordThatTestCasesWillNotRun returns true if no Test Case of the Unit remains running.
+func (p *testUnitProgress) recordThatTestCasesWillNotRun(numberOfCases int) (allTestCasesHaveFinished bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.numberOfCasesRemaining -= numberOfCases
+	p.someCasesWillNotRun = true
+	return p.numberOfCasesRemaining == 0
+}
+
+// resourceInformationList collects information about resources from concurrently running Test Cases.
+type resourceInformationList struct {
+	mutex     sync.Mutex
+	resources []*K8sResourceInformation
+}
+
+func (l *resourceInformationList) add(resources []*K8sResourceInformation) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.resources = append(l.resources, resources...)
+}
+
+func (l *resourceInformationList) list() []*K8sResourceInformation {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return append([]*K8sResourceInformation{}, l.resources...)
+}
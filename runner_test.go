@@ -0,0 +1,343 @@
+package jobber_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+)
+
+// runnerTestConfiguration reads configYaml, in which ActionDefinitionsRootDirectory and the AssetArchive FilePath
+// are filled in, so that a Test can be run against the fake API server.  executables/fails-when-told-to.sh fails
+// for a Unit or Case whose Values set Fail to true.
+func runnerTestConfiguration(t *testing.T, configYaml string) *jobber.Configuration {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	actionsRootDirectoryPath, err := filepath.Abs("testing_assets")
+	if err != nil {
+		t.Fatalf("failed to resolve testing_assets: %s", err)
+	}
+
+	configYaml = strings.ReplaceAll(configYaml, "<actions-root>", actionsRootDirectoryPath)
+	configYaml = strings.ReplaceAll(configYaml, "<archive>", filepath.Join(t.TempDir(), "archive.tar.gz"))
+
+	config, err := jobber.ReadConfigurationYamlFromReader(strings.NewReader(configYaml))
+	if err != nil {
+		t.Fatalf("failed to read configuration: %s", err)
+	}
+
+	return config
+}
+
+// eventsOfTestRun runs the Test with runner, and returns every event it sends.  The test fails if the Test does
+// not complete in time, as it would if RunTest() were to stop scheduling Test Cases.
+func eventsOfTestRun(t *testing.T, runner *jobber.Runner) []*jobber.Event {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	eventChannel := make(chan *jobber.Event)
+	go runner.RunTest(ctx, eventChannel)
+
+	events := make([]*jobber.Event, 0)
+	for event := range eventChannel {
+		events = append(events, event)
+	}
+
+	if ctx.Err() != nil {
+		t.Fatalf("the Test did not complete in time")
+	}
+
+	return events
+}
+
+func startedTestCasesIn(events []*jobber.Event) []string {
+	startedTestCases := make([]string, 0)
+	for _, event := range events {
+		if event.Type == jobber.TestCaseStarted {
+			startedTestCases = append(startedTestCases, event.Context.UnitName+"/"+event.Context.CaseName)
+		}
+	}
+	return startedTestCases
+}
+
+func TestRunTestSchedulesTestCases(t *testing.T) {
+	for _, testCase := range []struct {
+		testName                 string
+		configYaml               string
+		concurrency              uint
+		expectedStartedTestCases []string
+		expectedFinalEventType   jobber.EventType
+	}{
+		{
+			testName: "Concurrency of zero",
+			configYaml: `
+Test:
+  AssetArchive:
+    FilePath: <archive>
+  DefaultNamespace:
+    Basename: perftest
+  Pipeline:
+    ActionDefinitionsRootDirectory: <actions-root>
+    ActionsInOrder:
+      - executables/fails-when-told-to.sh
+  Cases:
+    - Name: 100TPS
+    - Name: 1000TPS
+  Units:
+    - Name: NoSidecar
+`,
+			concurrency:              0,
+			expectedStartedTestCases: []string{"NoSidecar/100TPS", "NoSidecar/1000TPS"},
+			expectedFinalEventType:   jobber.TestingCompletedSuccesfully,
+		},
+		{
+			testName: "Unit setup fails",
+			configYaml: `
+Test:
+  AssetArchive:
+    FilePath: <archive>
+  ContinueOnFailure: true
+  DefaultNamespace:
+    Basename: perftest
+  Pipeline:
+    ActionDefinitionsRootDirectory: <actions-root>
+    UnitSetup:
+      - executables/fails-when-told-to.sh
+    ActionsInOrder:
+      - executables/fails-when-told-to.sh
+  Cases:
+    - Name: 100TPS
+    - Name: 1000TPS
+  Units:
+    - Name: Broken
+      Values:
+        Fail: true
+    - Name: NoSidecar
+`,
+			concurrency:              1,
+			expectedStartedTestCases: []string{"NoSidecar/100TPS", "NoSidecar/1000TPS"},
+			expectedFinalEventType:   jobber.TestingFailed,
+		},
+		{
+			testName: "Test Case fails",
+			configYaml: `
+Test:
+  AssetArchive:
+    FilePath: <archive>
+  DefaultNamespace:
+    Basename: perftest
+  Pipeline:
+    ActionDefinitionsRootDirectory: <actions-root>
+    ActionsInOrder:
+      - executables/fails-when-told-to.sh
+  Cases:
+    - Name: 100TPS
+      Values:
+        Fail: true
+    - Name: 1000TPS
+  Units:
+    - Name: NoSidecar
+    - Name: Sidecar
+`,
+			concurrency:              1,
+			expectedStartedTestCases: []string{"NoSidecar/100TPS"},
+			expectedFinalEventType:   jobber.TestingFailed,
+		},
+		{
+			testName: "Test Case fails with ContinueOnFailure",
+			configYaml: `
+Test:
+  AssetArchive:
+    FilePath: <archive>
+  ContinueOnFailure: true
+  DefaultNamespace:
+    Basename: perftest
+  Pipeline:
+    ActionDefinitionsRootDirectory: <actions-root>
+    ActionsInOrder:
+      - executables/fails-when-told-to.sh
+  Cases:
+    - Name: 100TPS
+      Values:
+        Fail: true
+    - Name: 1000TPS
+  Units:
+    - Name: NoSidecar
+`,
+			concurrency:              1,
+			expectedStartedTestCases: []string{"NoSidecar/100TPS", "NoSidecar/1000TPS"},
+			expectedFinalEventType:   jobber.TestingFailed,
+		},
+	} {
+		config := runnerTestConfiguration(t, testCase.configYaml)
+		config.Test.Concurrency = testCase.concurrency
+
+		_, client := newFakeApiServer(t)
+		events := eventsOfTestRun(t, jobber.NewRunner(config, client))
+
+		if diff := deep.Equal(startedTestCasesIn(events), testCase.expectedStartedTestCases); diff != nil {
+			t.Errorf("[%s] started Test Cases differ from those expected: %v", testCase.testName, diff)
+		}
+
+		if finalEventType := events[len(events)-1].Type; finalEventType != testCase.expectedFinalEventType {
+			t.Errorf("[%s] expected final event type (%d), got (%d)", testCase.testName, testCase.expectedFinalEventType, finalEventType)
+		}
+	}
+}
+
+func TestRunTestIsolatesConcurrentTestCases(t *testing.T) {
+	config := runnerTestConfiguration(t, `
+Test:
+  AssetArchive:
+    FilePath: <archive>
+  Cleanup:
+    Policy: Never
+  Concurrency: 3
+  DefaultNamespace:
+    Basename: perftest
+  Pipeline:
+    ActionDefinitionsRootDirectory: <actions-root>
+    ActionsInOrder:
+      - executables/fails-when-told-to.sh
+  Cases:
+    - Name: 100TPS
+      Values:
+        TPS: 100
+    - Name: 1000TPS
+      Values:
+        TPS: 1000
+    - Name: 10000TPS
+      Values:
+        TPS: 10000
+  Units:
+    - Name: NoSidecar
+`)
+
+	_, client := newFakeApiServer(t)
+	events := eventsOfTestRun(t, jobber.NewRunner(config, client))
+
+	if finalEventType := events[len(events)-1].Type; finalEventType != jobber.TestingCompletedSuccesfully {
+		t.Fatalf("expected final event type (%d), got (%d)", jobber.TestingCompletedSuccesfully, finalEventType)
+	}
+
+	defaultNamespaceOf := make(map[string]string)
+	testCaseUsing := make(map[string]string)
+
+	for _, event := range events {
+		if event.Type == jobber.ResourceCreationSuccess && event.ResourceInformation.ResourceDetails.Kind == "namespace" {
+			namespaceName := event.ResourceInformation.ResourceDetails.Name
+			if otherTestCase, isUsed := testCaseUsing[namespaceName]; isUsed {
+				t.Errorf("[%s] expected its own default Namespace, but (%s) is also used by (%s)", event.Context.CaseName, namespaceName, otherTestCase)
+			}
+			defaultNamespaceOf[event.Context.CaseName] = namespaceName
+			testCaseUsing[namespaceName] = event.Context.CaseName
+		}
+	}
+
+	executableOutputs := filesUnder(t, events[len(events)-1].LeftBehindInformation.AssetsDirectoryPath)
+
+	for _, testCaseName := range []string{"100TPS", "1000TPS", "10000TPS"} {
+		stdout, exists := executableOutputs["NoSidecar/"+testCaseName+"/executable-output/fails-when-told-to.sh.stdout"]
+		if !exists {
+			t.Errorf("[%s] expected the executable output in the assets directory of the Test Case", testCaseName)
+			continue
+		}
+
+		var variables jobber.PipelineVariables
+		if err := json.Unmarshal([]byte(stdout), &variables); err != nil {
+			t.Fatalf("[%s] failed to decode the variables given to the executable: %s", testCaseName, err)
+		}
+
+		if variables.Context.TestCaseName != testCaseName {
+			t.Errorf("[%s] expected the executable to be given the Test Case name, got (%s)", testCaseName, variables.Context.TestCaseName)
+		}
+
+		if tps := fmt.Sprintf("%vTPS", variables.Values.Case["TPS"]); tps != testCaseName {
+			t.Errorf("[%s] expected the executable to be given the Values of the Test Case, got TPS (%s)", testCaseName, tps)
+		}
+
+		if defaultNamespaceOf[testCaseName] == "" || variables.Runtime.DefaultNamespace.Name != defaultNamespaceOf[testCaseName] {
+			t.Errorf("[%s] expected the executable to be given the default Namespace of the Test Case (%s), got (%s)", testCaseName, defaultNamespaceOf[testCaseName], variables.Runtime.DefaultNamespace.Name)
+		}
+	}
+}
+
+func TestRunTestResumesOnlyFromTheCheckpointOfTheSameTest(t *testing.T) {
+	configYaml := `
+Test:
+  AssetArchive:
+    FilePath: <archive>
+  ContinueOnFailure: true
+  DefaultNamespace:
+    Basename: perftest
+  Pipeline:
+    ActionDefinitionsRootDirectory: <actions-root>
+    ActionsInOrder:
+      - executables/fails-when-told-to.sh
+  Cases:
+    - Name: 100TPS
+      Values:
+        Fail: true
+    - Name: 1000TPS
+  Units:
+    - Name: NoSidecar
+`
+
+	_, client := newFakeApiServer(t)
+	config := runnerTestConfiguration(t, configYaml)
+
+	var checkpointFilePath string
+	for _, event := range eventsOfTestRun(t, jobber.NewRunner(config, client)) {
+		if event.Type == jobber.CheckpointFileRetained {
+			checkpointFilePath = event.FileEvent.Path
+		}
+	}
+
+	if checkpointFilePath == "" {
+		t.Fatalf("expected the checkpoint file to be retained after a Test Case failed")
+	}
+
+	changedConfig := runnerTestConfiguration(t, configYaml)
+	changedConfig.Test.AssetArchive.FilePath = config.Test.AssetArchive.FilePath
+	changedConfig.Test.Cases[1].Values["TPS"] = 1000
+
+	for _, testCase := range []struct {
+		testName                 string
+		config                   *jobber.Configuration
+		expectedStartedTestCases []string
+		expectRefusal            bool
+	}{
+		{
+			testName:                 "changed configuration",
+			config:                   changedConfig,
+			expectedStartedTestCases: []string{},
+			expectRefusal:            true,
+		},
+		{
+			testName:                 "same configuration",
+			config:                   config,
+			expectedStartedTestCases: []string{"NoSidecar/100TPS"},
+		},
+	} {
+		checkpoint, err := jobber.ReadTestCheckpointFromFile(checkpointFilePath)
+		if err != nil {
+			t.Fatalf("[%s] on ReadTestCheckpointFromFile(), expected no error, got error = (%s)", testCase.testName, err)
+		}
+
+		events := eventsOfTestRun(t, jobber.NewRunner(testCase.config, client).ResumingFrom(checkpoint))
+
+		if diff := deep.Equal(startedTestCasesIn(events), testCase.expectedStartedTestCases); diff != nil {
+			t.Errorf("[%s] started Test Cases differ from those expected: %v", testCase.testName, diff)
+		}
+
+		if refused := events[len(events)-1].Type == jobber.CheckpointDoesNotMatchTest; refused != testCase.expectRefusal {
+			t.Errorf("[%s] expected refusal to resume to be (%t), got (%t)", testCase.testName, testCase.expectRefusal, refused)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package jobber
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// testSelectionFileName is the name of the file, in the assets root directory, that records the selection.
+const testSelectionFileName = "selection.txt"
+
+// namePattern matches Test Unit or Test Case names.  A pattern delimited by slashes (e.g., /^With.*/) is a regular
+// expression, which matches if it matches any part of a name.  Any other pattern is a glob (e.g., With*), which
+// must match the entire name.
+type namePattern struct {
+	pattern string
+	regex   *regexp.Regexp
+}
+
+func newNamePattern(pattern string) (*namePattern, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern cannot be empty")
+	}
+
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		regex, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("pattern (%s) is not a valid regular expression: %s", pattern, err)
+		}
+
+		return &namePattern{pattern: pattern, regex: regex}, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("pattern (%s) is not a valid glob: %s", pattern, err)
+	}
+
+	return &namePattern{pattern: pattern}, nil
+}
+
+func (p *namePattern) matches(name string) bool {
+	if p.regex != nil {
+		return p.regex.MatchString(name)
+	}
+
+	matched, _ := path.Match(p.pattern, name)
+	return matched
+}
+
+func (p *namePattern) String() string {
+	return p.pattern
+}
+
+type unitAndCasePattern struct {
+	unitPattern *namePattern
+	casePattern *namePattern
+}
+
+// TestSelection limits the Test Units and Test Cases that a Test runs.  A Test Case of a Test Unit is selected
+// if it satisfies every kind of selector that has been added: at least one unit pattern matches the Unit name, at
+// least one case pattern matches the Case name, at least one unit/case pattern matches both, and at least one tag
+// is carried by the Unit or the Case.  A TestSelection to which no selectors have been added selects everything.
+type TestSelection struct {
+	unitPatterns        []*namePattern
+	casePatterns        []*namePattern
+	unitAndCasePatterns []*unitAndCasePattern
+	tags                []string
+}
+
+func NewTestSelection() *TestSelection {
+	return &TestSelection{
+		unitPatterns:        make([]*namePattern, 0),
+		casePatterns:        make([]*namePattern, 0),
+		unitAndCasePatterns: make([]*unitAndCasePattern, 0),
+		tags:                make([]string, 0),
+	}
+}
+
+// AddUnitPattern adds a glob or regular expression that selects Test Units by name.
+func (selection *TestSelection) AddUnitPattern(pattern string) error {
+	p, err := newNamePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	selection.unitPatterns = append(selection.unitPatterns, p)
+	return nil
+}
+
+// AddCasePattern adds a glob or regular expression that selects Test Cases by name.
+func (selection *TestSelection) AddCasePattern(pattern string) error {
+	p, err := newNamePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	selection.casePatterns = append(selection.casePatterns, p)
+	return nil
+}
+
+// AddUnitAndCasePattern adds a selector of the form <unit-pattern>/<case-pattern>, which selects a Test Case
+// only for matching Test Units.  If the unit pattern is a regular expression, it may itself contain slashes
+// (e.g., unit /^With/ and case /000TPS$/ are written as /^With///000TPS$/).
+func (selection *TestSelection) AddUnitAndCasePattern(pattern string) error {
+	separatorIndex := strings.Index(pattern, "/")
+	if separatorIndex == 0 {
+		if closingIndex := strings.Index(pattern[1:], "/"); closingIndex >= 0 {
+			separatorIndex = closingIndex + 2
+		} else {
+			separatorIndex = -1
+		}
+	}
+
+	if separatorIndex < 0 || separatorIndex >= len(pattern) || pattern[separatorIndex] != '/' {
+		return fmt.Errorf("selector (%s) must be of the form <unit>/<case>", pattern)
+	}
+
+	unitPattern, err := newNamePattern(pattern[:separatorIndex])
+	if err != nil {
+		return fmt.Errorf("selector (%s) unit: %s", pattern, err)
+	}
+
+	casePattern, err := newNamePattern(pattern[separatorIndex+1:])
+	if err != nil {
+		return fmt.Errorf("selector (%s) case: %s", pattern, err)
+	}
+
+	selection.unitAndCasePatterns = append(selection.unitAndCasePatterns, &unitAndCasePattern{unitPattern, casePattern})
+	return nil
+}
+
+// AddTag adds a tag that selects Test Units and Test Cases that carry it.
+func (selection *TestSelection) AddTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag cannot be empty")
+	}
+
+	selection.tags = append(selection.tags, tag)
+	return nil
+}
+
+// SelectsEverything returns true if no selectors have been added.
+func (selection *TestSelection) SelectsEverything() bool {
+	return len(selection.unitPatterns) == 0 && len(selection.casePatterns) == 0 && len(selection.unitAndCasePatterns) == 0 && len(selection.tags) == 0
+}
+
+// Selects returns true if testCase should be run for testUnit.
+func (selection *TestSelection) Selects(testUnit *TestUnit, testCase *TestCase) bool {
+	if len(selection.unitPatterns) > 0 && !anyPatternMatches(selection.unitPatterns, testUnit.Name) {
+		return false
+	}
+
+	if len(selection.casePatterns) > 0 && !anyPatternMatches(selection.casePatterns, testCase.Name) {
+		return false
+	}
+
+	if len(selection.unitAndCasePatterns) > 0 {
+		matched := false
+		for _, p := range selection.unitAndCasePatterns {
+			if p.unitPattern.matches(testUnit.Name) && p.casePattern.matches(testCase.Name) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if len(selection.tags) > 0 && !anyTagIsIn(selection.tags, testUnit.Tags) && !anyTagIsIn(selection.tags, testCase.Tags) {
+		return false
+	}
+
+	return true
+}
+
+// SelectsAnythingIn returns true if at least one Test Case of at least one Test Unit in config is selected.
+func (selection *TestSelection) SelectsAnythingIn(config *Configuration) bool {
+	for _, testUnit := range config.Test.Units {
+		for _, testCase := range config.Test.Cases {
+			if selection.Selects(testUnit, testCase) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// String describes the selectors, one kind per line.
+func (selection *TestSelection) String() string {
+	if selection.SelectsEverything() {
+		return "all units and cases\n"
+	}
+
+	var b strings.Builder
+
+	if len(selection.unitPatterns) > 0 {
+		fmt.Fprintf(&b, "units: %s\n", joinPatterns(selection.unitPatterns))
+	}
+
+	if len(selection.casePatterns) > 0 {
+		fmt.Fprintf(&b, "cases: %s\n", joinPatterns(selection.casePatterns))
+	}
+
+	if len(selection.unitAndCasePatterns) > 0 {
+		s := make([]string, len(selection.unitAndCasePatterns))
+		for i, p := range selection.unitAndCasePatterns {
+			s[i] = fmt.Sprintf("%s/%s", p.unitPattern, p.casePattern)
+		}
+		fmt.Fprintf(&b, "only: %s\n", strings.Join(s, ", "))
+	}
+
+	if len(selection.tags) > 0 {
+		fmt.Fprintf(&b, "tags: %s\n", strings.Join(selection.tags, ", "))
+	}
+
+	return b.String()
+}
+
+func anyPatternMatches(patterns []*namePattern, name string) bool {
+	for _, p := range patterns {
+		if p.matches(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func anyTagIsIn(tags []string, carriedTags []string) bool {
+	for _, tag := range tags {
+		for _, carriedTag := range carriedTags {
+			if tag == carriedTag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func joinPatterns(patterns []*namePattern) string {
+	s := make([]string, len(patterns))
+	for i, p := range patterns {
+		s[i] = p.String()
+	}
+
+	return strings.Join(s, ", ")
+}
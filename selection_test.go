@@ -0,0 +1,147 @@
+package jobber_test
+
+import (
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+)
+
+func TestSelection(t *testing.T) {
+	units := []*jobber.TestUnit{
+		{Name: "NoTelemetry"},
+		{Name: "WithTelemetry", Tags: []string{"telemetry"}},
+	}
+
+	cases := []*jobber.TestCase{
+		{Name: "100TPS", Tags: []string{"smoke"}},
+		{Name: "1000TPS"},
+	}
+
+	for _, testCase := range []struct {
+		testName           string
+		unitPatterns       []string
+		casePatterns       []string
+		onlyPatterns       []string
+		tags               []string
+		expectAnError      bool
+		expectedlySelected []string
+	}{
+		{
+			testName:           "no selectors",
+			expectedlySelected: []string{"NoTelemetry/100TPS", "NoTelemetry/1000TPS", "WithTelemetry/100TPS", "WithTelemetry/1000TPS"},
+		},
+		{
+			testName:           "unit glob",
+			unitPatterns:       []string{"With*"},
+			expectedlySelected: []string{"WithTelemetry/100TPS", "WithTelemetry/1000TPS"},
+		},
+		{
+			testName:           "case regex matches any part of name",
+			casePatterns:       []string{"/000/"},
+			expectedlySelected: []string{"NoTelemetry/1000TPS", "WithTelemetry/1000TPS"},
+		},
+		{
+			testName:           "unit and case glob",
+			onlyPatterns:       []string{"WithTelemetry/1000TPS"},
+			expectedlySelected: []string{"WithTelemetry/1000TPS"},
+		},
+		{
+			testName:           "unit and case regex",
+			onlyPatterns:       []string{"/^No///^100T/", "With*/1000TPS"},
+			expectedlySelected: []string{"NoTelemetry/100TPS", "WithTelemetry/1000TPS"},
+		},
+		{
+			testName:           "tags on unit or case",
+			tags:               []string{"telemetry", "smoke"},
+			expectedlySelected: []string{"NoTelemetry/100TPS", "WithTelemetry/100TPS", "WithTelemetry/1000TPS"},
+		},
+		{
+			testName:           "different selector kinds must all match",
+			unitPatterns:       []string{"No*"},
+			tags:               []string{"smoke"},
+			expectedlySelected: []string{"NoTelemetry/100TPS"},
+		},
+		{
+			testName:           "nothing matches",
+			casePatterns:       []string{"5000TPS"},
+			expectedlySelected: []string{},
+		},
+		{
+			testName:      "invalid regex",
+			unitPatterns:  []string{"/(/"},
+			expectAnError: true,
+		},
+		{
+			testName:      "invalid glob",
+			casePatterns:  []string{"[100"},
+			expectAnError: true,
+		},
+		{
+			testName:      "only without case",
+			onlyPatterns:  []string{"WithTelemetry"},
+			expectAnError: true,
+		},
+		{
+			testName:      "only with unterminated regex",
+			onlyPatterns:  []string{"/With/"},
+			expectAnError: true,
+		},
+	} {
+		selection := jobber.NewTestSelection()
+
+		var err error
+		for _, p := range testCase.unitPatterns {
+			if err == nil {
+				err = selection.AddUnitPattern(p)
+			}
+		}
+		for _, p := range testCase.casePatterns {
+			if err == nil {
+				err = selection.AddCasePattern(p)
+			}
+		}
+		for _, p := range testCase.onlyPatterns {
+			if err == nil {
+				err = selection.AddUnitAndCasePattern(p)
+			}
+		}
+		for _, tag := range testCase.tags {
+			if err == nil {
+				err = selection.AddTag(tag)
+			}
+		}
+
+		if testCase.expectAnError {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got no error", testCase.testName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no error, got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		selected := make([]string, 0)
+		for _, unit := range units {
+			for _, c := range cases {
+				if selection.Selects(unit, c) {
+					selected = append(selected, unit.Name+"/"+c.Name)
+				}
+			}
+		}
+
+		if len(selected) != len(testCase.expectedlySelected) {
+			t.Errorf("[%s] expected selection (%v), got (%v)", testCase.testName, testCase.expectedlySelected, selected)
+			continue
+		}
+
+		for i := range selected {
+			if selected[i] != testCase.expectedlySelected[i] {
+				t.Errorf("[%s] expected selection (%v), got (%v)", testCase.testName, testCase.expectedlySelected, selected)
+				break
+			}
+		}
+	}
+}
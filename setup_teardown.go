@@ -0,0 +1,146 @@
+package jobber
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// setupAndTeardownScope holds what the setup and teardown Pipelines of the Test, or of a Test Unit, share: the
+// variables used to expand templates (including the default Namespace of the scope) and the tracker for the
+// resources that the Pipelines create.
+type setupAndTeardownScope struct {
+	// testUnit is nil for the Test scope.
+	testUnit         *TestUnit
+	setupPipeline    *Pipeline
+	teardownPipeline *Pipeline
+	variables        *PipelineVariables
+	resourceTracker  *CreatedResourceTracker
+
+	// setupPaths and teardownPaths are set once the assets directories have been created.
+	setupPaths    *TestCaseDirectoryPaths
+	teardownPaths *TestCaseDirectoryPaths
+	setupFailed   bool
+	teardownOnce  sync.Once
+}
+
+// newSetupAndTeardownScope returns the scope for the setup and teardown Pipeline Actions in setupEntries and
+// teardownEntries.  scopeVariables must not be shared with another scope.  testUnit is nil for the Test scope.
+func (runner *Runner) newSetupAndTeardownScope(setupEntries []*ConfigurationPipelineAction, teardownEntries []*ConfigurationPipelineAction, scopeVariables *PipelineVariables, testUnit *TestUnit) (*setupAndTeardownScope, error) {
+	setupPipeline, err := NewPipelineFromConfigurationEntries(setupEntries, runner.config.Test)
+	if err != nil {
+		return nil, err
+	}
+
+	teardownPipeline, err := NewPipelineFromConfigurationEntries(teardownEntries, runner.config.Test)
+	if err != nil {
+		return nil, err
+	}
+
+	return &setupAndTeardownScope{
+		testUnit:         testUnit,
+		setupPipeline:    setupPipeline,
+		teardownPipeline: teardownPipeline,
+		variables:        scopeVariables,
+		resourceTracker:  NewCreatedResourceTracker(),
+	}, nil
+}
+
+// isEmpty returns true if the scope has neither setup nor teardown Pipeline Actions, in which case nothing is done
+// for it.
+func (scope *setupAndTeardownScope) isEmpty() bool {
+	return scope.setupPipeline.NumberOfActions() == 0 && scope.teardownPipeline.NumberOfActions() == 0
+}
+
+// runSetup creates the setup and teardown assets directories and the default Namespace for scope, then runs the
+// setup Pipeline, stopping on the first failure.  Nothing is done for an empty scope.
+func (runner *Runner) runSetup(ctx context.Context, scope *setupAndTeardownScope, assetsDirectoryManager *ContextualAssetsDirectoryManager, eventHandler *eventHandler) error {
+	if scope.isEmpty() {
+		return nil
+	}
+
+	eventHandler.sayThatSetupStarted(scope.testUnit)
+
+	if err := runner.runSetupPipeline(ctx, scope, assetsDirectoryManager, eventHandler); err != nil {
+		scope.setupFailed = true
+		eventHandler.sayThatSetupFailed(scope.testUnit, err)
+		return err
+	}
+
+	eventHandler.sayThatSetupCompletedSuccessfully(scope.testUnit)
+	return nil
+}
+
+func (runner *Runner) runSetupPipeline(ctx context.Context, scope *setupAndTeardownScope, assetsDirectoryManager *ContextualAssetsDirectoryManager, eventHandler *eventHandler) error {
+	setupPaths, teardownPaths, outcome := assetsDirectoryManager.CreateSetupAndTeardownDirectories(scope.testUnit)
+	if eventHandler.explainAssetCreationOutcome(outcome, scope.testUnit, nil); outcome.DirectoryCreationFailureError != nil {
+		return outcome.DirectoryCreationFailureError
+	}
+
+	scope.setupPaths, scope.teardownPaths = setupPaths, teardownPaths
+	scope.variables.AndTestCaseRetrievedAssetsDirectoryAt(setupPaths.RetrievedAssets)
+
+	nsObject, err := runner.createDefaultNamespace(ctx, scope.variables, scope.resourceTracker)
+	if eventHandler.explainAttemptToCreateDefaultNamespace(nsObject, EventContextFor(scope.testUnit, nil), err); err != nil {
+		return err
+	}
+
+	scope.variables.AndUsingDefaultNamespaceNamed(nsObject.Name)
+
+	executionEnvironment := runner.executionEnvironmentFor(setupPaths)
+
+	if failures := runner.runPipeline(ctx, scope.setupPipeline, false, scope.variables, executionEnvironment, scope.resourceTracker, eventHandler, setupPaths, scope.testUnit, nil); len(failures) > 0 {
+		return failures[0].err
+	}
+
+	return nil
+}
+
+// runTeardown runs the teardown Pipeline of scope, then deletes the resources created by the setup and teardown
+// Pipelines if .Test.Cleanup.Policy calls for it.  The scope has succeeded if scopeSucceeded is true and neither
+// Pipeline failed.  Teardown proceeds even if ctx has been cancelled, and every teardown Pipeline Action is run,
+// even after one fails.  If the assets directories could not be created, only the resources are deleted.  Teardown
+// happens only once for a scope; later calls do nothing.  The resources that are left behind are returned, along
+// with the first error.
+func (runner *Runner) runTeardown(ctx context.Context, scope *setupAndTeardownScope, scopeSucceeded bool, eventHandler *eventHandler) (leftBehind []*K8sResourceInformation, err error) {
+	scope.teardownOnce.Do(func() {
+		if scope.isEmpty() {
+			return
+		}
+
+		testWasCancelled := ctx.Err() != nil
+
+		eventHandler.sayThatTeardownStarted(scope.testUnit)
+
+		if scope.teardownPaths != nil {
+			teardownCtx := context.WithoutCancel(ctx)
+			scope.variables.AndTestCaseRetrievedAssetsDirectoryAt(scope.teardownPaths.RetrievedAssets)
+			executionEnvironment := runner.executionEnvironmentFor(scope.teardownPaths)
+
+			if failures := runner.runPipeline(teardownCtx, scope.teardownPipeline, true, scope.variables, executionEnvironment, scope.resourceTracker, eventHandler, scope.teardownPaths, scope.testUnit, nil); len(failures) > 0 {
+				err = fmt.Errorf("teardown action (%s) failed: %w", failures[0].action.Descriptor, failures[0].err)
+			}
+		}
+
+		if scope.teardownPaths != nil {
+			runner.capturePodLogs(ctx, scope.resourceTracker, scope.teardownPaths, eventHandler, scope.testUnit, nil)
+		}
+
+		scopeSucceeded = scopeSucceeded && !scope.setupFailed && err == nil
+
+		deletionAttempts := scope.resourceTracker.AttemptToDeleteResourcesAccordingTo(runner.config.Test.Cleanup.Policy, scopeSucceeded, testWasCancelled)
+		if deletionErr := runner.reportResourceDeletionAttempts(deletionAttempts, eventHandler, scope.testUnit, nil); deletionErr != nil && err == nil {
+			err = deletionErr
+		}
+
+		leftBehind = scope.resourceTracker.UndeletedResources()
+
+		if err != nil {
+			eventHandler.sayThatTeardownFailed(scope.testUnit, err)
+		} else {
+			eventHandler.sayThatTeardownCompletedSuccessfully(scope.testUnit)
+		}
+	})
+
+	return leftBehind, err
+}
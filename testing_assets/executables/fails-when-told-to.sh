@@ -0,0 +1,9 @@
+#!/bin/sh
+
+# Echoes the values and context, then fails if the Unit or Case Values set Fail to true
+variables=$(cat)
+echo "$variables"
+
+case "$variables" in
+    *'"Fail":true'*) exit 1 ;;
+esac
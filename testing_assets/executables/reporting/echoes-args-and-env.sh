@@ -0,0 +1,4 @@
+#!/bin/sh
+
+echo "$@"
+echo "$REPORT_FORMAT"
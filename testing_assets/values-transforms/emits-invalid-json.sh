@@ -0,0 +1,5 @@
+#!/bin/sh
+
+cat > /dev/null
+echo "this is not json"
+echo "transform failed" >&2
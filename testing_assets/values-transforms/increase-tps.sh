@@ -0,0 +1,24 @@
+#!/bin/sh
+
+cat > /dev/null
+
+cat <<JSON
+{
+  "Values": {
+    "Global": {},
+    "Unit": {
+      "Sidecar": {
+        "Inject": true
+      }
+    },
+    "Case": {
+      "TPS": 200
+    }
+  },
+  "Context": {
+    "TestUnitName": "unit01",
+    "TestCaseName": "case01",
+    "TestCaseRetrievedAssetsDirectoryPath": "/tmp/case"
+  }
+}
+JSON
@@ -0,0 +1,121 @@
+package jobber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// WaitTimeoutAnnotation, on a resource in a resources template, overrides the wait timeout of the Pipeline
+	// Action for that resource.  The value is a duration (e.g., 10m).
+	WaitTimeoutAnnotation = "jobber.blorticus-go.github.io/wait-timeout"
+
+	// ProbeIntervalAnnotation, on a resource in a resources template, overrides the probe interval of the Pipeline
+	// Action for that resource.  The value is a duration (e.g., 5s).
+	ProbeIntervalAnnotation = "jobber.blorticus-go.github.io/probe-interval"
+)
+
+// These apply to a wait when no timeout or probe interval is configured.  A Job has no default timeout, since a
+// load generating Job may legitimately run for hours.
+const (
+	defaultPodRunningStateTimeout       = 60 * time.Second
+	defaultPodRunningStateProbeInterval = time.Second
+	defaultJobCompletionProbeInterval   = 10 * time.Second
+	defaultReadinessTimeout             = 5 * time.Minute
+	defaultReadinessProbeInterval       = 2 * time.Second
+)
+
+// ActionTimeouts bound how long a Pipeline Action may run.  Action limits the whole action, including any waits
+// and processes.  Wait limits each wait for a created resource to reach its expected state, and ProbeInterval is
+// how often the resource is checked during such a wait.  A zero value means that Action is not limited, or that
+// the default for the kind of resource applies to Wait and ProbeInterval.
+type ActionTimeouts struct {
+	Action        time.Duration
+	Wait          time.Duration
+	ProbeInterval time.Duration
+}
+
+// NewActionTimeoutsFromConfiguration returns the timeouts for a .Test.Pipeline.ActionsInOrder entry.  A value set
+// on the entry takes precedence over the corresponding value in testWideTimeouts, which may be nil.
+func NewActionTimeoutsFromConfiguration(entry *ConfigurationPipelineAction, testWideTimeouts *ConfigurationTimeouts) *ActionTimeouts {
+	if testWideTimeouts == nil {
+		testWideTimeouts = &ConfigurationTimeouts{}
+	}
+
+	return &ActionTimeouts{
+		Action:        firstNonZeroDuration(entry.Timeout, testWideTimeouts.Action),
+		Wait:          firstNonZeroDuration(entry.WaitTimeout, testWideTimeouts.Wait),
+		ProbeInterval: firstNonZeroDuration(entry.ProbeInterval, testWideTimeouts.ProbeInterval),
+	}
+}
+
+// waitLimitsFor returns the wait timeout and probe interval for resource, which are those of timeouts (which may
+// be nil) unless resource overrides them with annotations.  A zero value means that the default applies.
+func (timeouts *ActionTimeouts) waitLimitsFor(resource *GenericK8sResource) (waitTimeout time.Duration, probeInterval time.Duration, err error) {
+	if timeouts != nil {
+		waitTimeout, probeInterval = timeouts.Wait, timeouts.ProbeInterval
+	}
+
+	annotations := resource.ApiObject().GetAnnotations()
+
+	if value, isSet := annotations[WaitTimeoutAnnotation]; isSet {
+		if waitTimeout, err = parsePositiveDuration(value); err != nil {
+			return 0, 0, fmt.Errorf("annotation (%s) on resource (%s) is invalid: %s", WaitTimeoutAnnotation, resource.Information().Name, err)
+		}
+	}
+
+	if value, isSet := annotations[ProbeIntervalAnnotation]; isSet {
+		if probeInterval, err = parsePositiveDuration(value); err != nil {
+			return 0, 0, fmt.Errorf("annotation (%s) on resource (%s) is invalid: %s", ProbeIntervalAnnotation, resource.Information().Name, err)
+		}
+	}
+
+	return waitTimeout, probeInterval, nil
+}
+
+// withActionTimeout returns a context that is cancelled when the Action timeout passes, if there is one.  The
+// cause of that cancellation wraps ErrorTimeExceeded.
+func (timeouts *ActionTimeouts) withActionTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeouts == nil || timeouts.Action <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeoutCause(ctx, timeouts.Action, fmt.Errorf("%w: action did not complete within %s", ErrorTimeExceeded, timeouts.Action))
+}
+
+// explainedIfActionTimedOut returns the cause of the cancellation of ctx if err is not nil and ctx was cancelled
+// because the Action timeout passed.  Otherwise, it returns err.
+func explainedIfActionTimedOut(ctx context.Context, err error) error {
+	if err != nil {
+		if cause := context.Cause(ctx); errors.Is(cause, ErrorTimeExceeded) {
+			return cause
+		}
+	}
+
+	return err
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("duration (%s) must be greater than zero", value)
+	}
+
+	return d, nil
+}
+
+func firstNonZeroDuration(durations ...time.Duration) time.Duration {
+	for _, d := range durations {
+		if d != 0 {
+			return d
+		}
+	}
+
+	return 0
+}
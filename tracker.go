@@ -1,8 +1,19 @@
 package jobber
 
+import "sync"
+
 type DeletableK8sResource struct {
 	information    *K8sResourceInformation
 	deletionMethod func(object any) error
+
+	// resource is nil for a resource that is not in the Runtime values (e.g., the default Namespace).
+	resource *GenericK8sResource
+
+	// restoresAPatch is true if "deleting" the resource restores an object that was patched or applied.
+	restoresAPatch bool
+
+	// dependencyDepth is that of the Pipeline Action that created the resource, or zero.
+	dependencyDepth int
 }
 
 type ResourceDeletionAttempt struct {
@@ -10,7 +21,10 @@ type ResourceDeletionAttempt struct {
 	Error    error
 }
 
+// CreatedResourceTracker tracks created resources so that they can be deleted.  Resources may be added by
+// concurrently running Pipeline Actions.
 type CreatedResourceTracker struct {
+	mutex                     sync.Mutex
 	notYetDeletedK8sResources []*DeletableK8sResource
 }
 
@@ -21,22 +35,100 @@ func NewCreatedResourceTracker() *CreatedResourceTracker {
 }
 
 func (tracker *CreatedResourceTracker) AddCreatedResource(r *DeletableK8sResource) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
 	tracker.notYetDeletedK8sResources = append(tracker.notYetDeletedK8sResources, r)
 }
 
+// AddResourcesTrackedBy adds the as yet undeleted resources of other to tracker, preserving their order of creation.
+func (tracker *CreatedResourceTracker) AddResourcesTrackedBy(other *CreatedResourceTracker) {
+	other.mutex.Lock()
+	resourcesTrackedByOther := append([]*DeletableK8sResource{}, other.notYetDeletedK8sResources...)
+	other.mutex.Unlock()
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.notYetDeletedK8sResources = append(tracker.notYetDeletedK8sResources, resourcesTrackedByOther...)
+}
+
+// AttemptToDeleteAllAsYetUndeletedResources deletes the tracked resources, deepest dependency first and otherwise in
+// reverse order of creation, stopping on the first failure.
 func (tracker *CreatedResourceTracker) AttemptToDeleteAllAsYetUndeletedResources() []*ResourceDeletionAttempt {
+	return tracker.attemptToDeleteResourcesThat(func(r *DeletableK8sResource) bool { return true })
+}
+
+// AttemptToDeleteResourcesAccordingTo deletes the tracked resources if policy calls for cleanup after a Test Case
+// with the given outcome.  Otherwise, only patched objects are restored.
+func (tracker *CreatedResourceTracker) AttemptToDeleteResourcesAccordingTo(policy CleanupPolicy, testCaseSucceeded bool, testCaseWasCancelled bool) []*ResourceDeletionAttempt {
+	if !policy.cleansUpAfter(testCaseSucceeded, testCaseWasCancelled) {
+		return tracker.attemptToDeleteResourcesThat(func(r *DeletableK8sResource) bool { return r.restoresAPatch })
+	}
+
+	return tracker.AttemptToDeleteAllAsYetUndeletedResources()
+}
+
+// attemptToDeleteResourcesThat deletes, in the same order, the tracked resources for which isToBeDeleted returns
+// true.
+func (tracker *CreatedResourceTracker) attemptToDeleteResourcesThat(isToBeDeleted func(r *DeletableK8sResource) bool) []*ResourceDeletionAttempt {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
 	deletionAttempts := make([]*ResourceDeletionAttempt, 0, len(tracker.notYetDeletedK8sResources))
 
-	for len(tracker.notYetDeletedK8sResources) > 0 {
-		r := tracker.notYetDeletedK8sResources[len(tracker.notYetDeletedK8sResources)-1]
+	for {
+		indexOfNextToDelete := -1
+		for i, r := range tracker.notYetDeletedK8sResources {
+			if !isToBeDeleted(r) {
+				continue
+			}
+			if indexOfNextToDelete < 0 || r.dependencyDepth >= tracker.notYetDeletedK8sResources[indexOfNextToDelete].dependencyDepth {
+				indexOfNextToDelete = i
+			}
+		}
+
+		if indexOfNextToDelete < 0 {
+			return deletionAttempts
+		}
+
+		r := tracker.notYetDeletedK8sResources[indexOfNextToDelete]
 		err := r.deletionMethod(r)
 		deletionAttempts = append(deletionAttempts, &ResourceDeletionAttempt{r, err})
 		if err != nil {
 			return deletionAttempts
 		}
 
-		tracker.notYetDeletedK8sResources = tracker.notYetDeletedK8sResources[:len(tracker.notYetDeletedK8sResources)-1]
+		tracker.notYetDeletedK8sResources = append(tracker.notYetDeletedK8sResources[:indexOfNextToDelete], tracker.notYetDeletedK8sResources[indexOfNextToDelete+1:]...)
+	}
+}
+
+// UndeletedResources returns information about the tracked resources that have not been deleted, in order of
+// creation.
+func (tracker *CreatedResourceTracker) UndeletedResources() []*K8sResourceInformation {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	resources := make([]*K8sResourceInformation, len(tracker.notYetDeletedK8sResources))
+	for i, r := range tracker.notYetDeletedK8sResources {
+		resources[i] = r.information
+	}
+
+	return resources
+}
+
+// undeletedRuntimeResources returns the tracked resources that have not been deleted and that are in the Runtime
+// values, in order of creation.
+func (tracker *CreatedResourceTracker) undeletedRuntimeResources() []*GenericK8sResource {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	resources := make([]*GenericK8sResource, 0, len(tracker.notYetDeletedK8sResources))
+	for _, r := range tracker.notYetDeletedK8sResources {
+		if r.resource != nil {
+			resources = append(resources, r.resource)
+		}
 	}
 
-	return deletionAttempts
+	return resources
 }
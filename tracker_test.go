@@ -0,0 +1,143 @@
+package jobber_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"github.com/go-test/deep"
+)
+
+type trackedResourceForTest struct {
+	name            string
+	dependencyDepth int
+	restoresAPatch  bool
+	deletionFails   bool
+}
+
+// trackerOf returns a tracker holding resources, in order of creation, and the list to which the name of each
+// resource is appended when there is an attempt to delete it.
+func trackerOf(resources []trackedResourceForTest) (*jobber.CreatedResourceTracker, *[]string) {
+	tracker := jobber.NewCreatedResourceTracker()
+	deletionAttempts := make([]string, 0)
+
+	for _, r := range resources {
+		r := r
+		tracker.AddCreatedResource(jobber.NewDeletableK8sResourceForTest(&jobber.K8sResourceInformation{Kind: "ConfigMap", Name: r.name}, r.dependencyDepth, r.restoresAPatch, func(object any) error {
+			deletionAttempts = append(deletionAttempts, r.name)
+			if r.deletionFails {
+				return fmt.Errorf("cannot delete (%s)", r.name)
+			}
+			return nil
+		}))
+	}
+
+	return tracker, &deletionAttempts
+}
+
+func namesOfUndeletedResourcesIn(tracker *jobber.CreatedResourceTracker) []string {
+	names := make([]string, 0)
+	for _, information := range tracker.UndeletedResources() {
+		names = append(names, information.Name)
+	}
+	return names
+}
+
+func TestCreatedResourceTrackerDeletionOrder(t *testing.T) {
+	for _, testCase := range []struct {
+		testName                 string
+		resources                []trackedResourceForTest
+		expectedDeletionAttempts []string
+		expectedUndeleted        []string
+		expectAnError            bool
+	}{
+		{
+			testName:                 "sequential Pipeline",
+			resources:                []trackedResourceForTest{{name: "a"}, {name: "b"}, {name: "c"}},
+			expectedDeletionAttempts: []string{"c", "b", "a"},
+			expectedUndeleted:        []string{},
+		},
+		{
+			testName: "dependency graph",
+			resources: []trackedResourceForTest{
+				{name: "namespace"},
+				{name: "producer", dependencyDepth: 1},
+				{name: "telemetry", dependencyDepth: 1},
+				{name: "load-generator", dependencyDepth: 2},
+				{name: "settings", dependencyDepth: 1},
+			},
+			expectedDeletionAttempts: []string{"load-generator", "settings", "telemetry", "producer", "namespace"},
+			expectedUndeleted:        []string{},
+		},
+		{
+			testName:                 "failed deletion",
+			resources:                []trackedResourceForTest{{name: "a"}, {name: "b", deletionFails: true}, {name: "c"}},
+			expectedDeletionAttempts: []string{"c", "b"},
+			expectedUndeleted:        []string{"a", "b"},
+			expectAnError:            true,
+		},
+	} {
+		tracker, deletionAttempts := trackerOf(testCase.resources)
+
+		attempts := tracker.AttemptToDeleteAllAsYetUndeletedResources()
+
+		if diff := deep.Equal(*deletionAttempts, testCase.expectedDeletionAttempts); diff != nil {
+			t.Errorf("[%s] deletion attempts differ from those expected: %v", testCase.testName, diff)
+		}
+
+		if len(attempts) != len(testCase.expectedDeletionAttempts) {
+			t.Errorf("[%s] expected (%d) deletion attempts to be reported, got (%d)", testCase.testName, len(testCase.expectedDeletionAttempts), len(attempts))
+		} else if lastError := attempts[len(attempts)-1].Error; (lastError != nil) != testCase.expectAnError {
+			t.Errorf("[%s] expected last deletion attempt to fail = (%t), got error = (%v)", testCase.testName, testCase.expectAnError, lastError)
+		}
+
+		if diff := deep.Equal(namesOfUndeletedResourcesIn(tracker), testCase.expectedUndeleted); diff != nil {
+			t.Errorf("[%s] undeleted resources differ from those expected: %v", testCase.testName, diff)
+		}
+	}
+}
+
+func TestCreatedResourceTrackerDeletionAccordingToCleanupPolicy(t *testing.T) {
+	resources := []trackedResourceForTest{
+		{name: "namespace"},
+		{name: "mesh-wide", restoresAPatch: true},
+		{name: "producer", dependencyDepth: 1},
+		{name: "istio-config", dependencyDepth: 1, restoresAPatch: true},
+	}
+
+	everything := []string{"istio-config", "producer", "mesh-wide", "namespace"}
+	onlyPatches := []string{"istio-config", "mesh-wide"}
+	createdResources := []string{"namespace", "producer"}
+
+	for _, testCase := range []struct {
+		policy                   jobber.CleanupPolicy
+		succeeded                bool
+		cancelled                bool
+		expectedDeletionAttempts []string
+		expectedUndeleted        []string
+	}{
+		{policy: jobber.CleanupAlways, succeeded: true, expectedDeletionAttempts: everything, expectedUndeleted: []string{}},
+		{policy: jobber.CleanupAlways, succeeded: false, expectedDeletionAttempts: everything, expectedUndeleted: []string{}},
+		{policy: jobber.CleanupAlways, succeeded: false, cancelled: true, expectedDeletionAttempts: everything, expectedUndeleted: []string{}},
+		{policy: jobber.CleanupOnSuccess, succeeded: true, expectedDeletionAttempts: everything, expectedUndeleted: []string{}},
+		{policy: jobber.CleanupOnSuccess, succeeded: false, expectedDeletionAttempts: onlyPatches, expectedUndeleted: createdResources},
+		{policy: jobber.CleanupOnSuccess, succeeded: false, cancelled: true, expectedDeletionAttempts: everything, expectedUndeleted: []string{}},
+		{policy: "", succeeded: false, expectedDeletionAttempts: onlyPatches, expectedUndeleted: createdResources},
+		{policy: jobber.CleanupNever, succeeded: true, expectedDeletionAttempts: onlyPatches, expectedUndeleted: createdResources},
+		{policy: jobber.CleanupNever, succeeded: false, expectedDeletionAttempts: onlyPatches, expectedUndeleted: createdResources},
+		{policy: jobber.CleanupNever, succeeded: false, cancelled: true, expectedDeletionAttempts: onlyPatches, expectedUndeleted: createdResources},
+	} {
+		testName := fmt.Sprintf("policy (%s), succeeded = %t, cancelled = %t", testCase.policy, testCase.succeeded, testCase.cancelled)
+		tracker, deletionAttempts := trackerOf(resources)
+
+		tracker.AttemptToDeleteResourcesAccordingTo(testCase.policy, testCase.succeeded, testCase.cancelled)
+
+		if diff := deep.Equal(*deletionAttempts, testCase.expectedDeletionAttempts); diff != nil {
+			t.Errorf("[%s] deletion attempts differ from those expected: %v", testName, diff)
+		}
+
+		if diff := deep.Equal(namesOfUndeletedResourcesIn(tracker), testCase.expectedUndeleted); diff != nil {
+			t.Errorf("[%s] undeleted resources differ from those expected: %v", testName, diff)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package jobber
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/qdm12/reprint"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,8 +26,11 @@ type PipelineRuntimeNamespace struct {
 	Name string
 }
 
+// PipelineRuntimeValues are the values that jobber manages as a Pipeline runs.  Resources may be added and looked
+// up by concurrently running Pipeline Actions (i.e., the members of a parallel group).
 type PipelineRuntimeValues struct {
 	DefaultNamespace *PipelineRuntimeNamespace
+	mutex            sync.RWMutex
 	createdAssets    map[gvkKey]map[resourceName]*GenericK8sResource
 	client           *Client
 }
@@ -43,6 +48,9 @@ func NewEmptyPipelineRuntimeValues(client *Client) *PipelineRuntimeValues {
 func (values *PipelineRuntimeValues) Add(resource *GenericK8sResource) *PipelineRuntimeValues {
 	key := gvkKeyFromGroupVersionKind(resource.ApiObject().GroupVersionKind())
 
+	values.mutex.Lock()
+	defer values.mutex.Unlock()
+
 	if values.createdAssets[key] == nil {
 		values.createdAssets[key] = make(map[resourceName]*GenericK8sResource)
 	}
@@ -52,7 +60,49 @@ func (values *PipelineRuntimeValues) Add(resource *GenericK8sResource) *Pipeline
 	return values
 }
 
+// Remove removes resources that were added to values.  A resource that has since been replaced by another with the
+// same kind and name is not removed.
+func (values *PipelineRuntimeValues) Remove(resources ...*GenericK8sResource) *PipelineRuntimeValues {
+	values.mutex.Lock()
+	defer values.mutex.Unlock()
+
+	for _, resource := range resources {
+		resourcesByName := values.createdAssets[gvkKeyFromGroupVersionKind(resource.ApiObject().GroupVersionKind())]
+		if resourcesByName[resourceName(resource.Name)] == resource {
+			delete(resourcesByName, resourceName(resource.Name))
+		}
+	}
+
+	return values
+}
+
+// Copy returns a copy of values to which resources can be added without affecting values.  The resources
+// already added, and the client, are shared with values.
+func (values *PipelineRuntimeValues) Copy() *PipelineRuntimeValues {
+	values.mutex.RLock()
+	defer values.mutex.RUnlock()
+
+	createdAssetsCopy := make(map[gvkKey]map[resourceName]*GenericK8sResource, len(values.createdAssets))
+	for key, resourcesByName := range values.createdAssets {
+		createdAssetsCopy[key] = make(map[resourceName]*GenericK8sResource, len(resourcesByName))
+		for name, resource := range resourcesByName {
+			createdAssetsCopy[key][name] = resource
+		}
+	}
+
+	return &PipelineRuntimeValues{
+		DefaultNamespace: &PipelineRuntimeNamespace{
+			Name: values.DefaultNamespace.Name,
+		},
+		createdAssets: createdAssetsCopy,
+		client:        values.client,
+	}
+}
+
 func (values *PipelineRuntimeValues) CreatedAsset(group string, version string, kind string, name string) *GenericK8sResource {
+	values.mutex.RLock()
+	defer values.mutex.RUnlock()
+
 	return values.createdAssets[gvkKeyFromGVKStrings(group, version, kind)][resourceName(name)]
 }
 
@@ -86,6 +136,9 @@ type PipelineVariablesContext struct {
 	TestUnitName                         string
 	TestCaseName                         string
 	TestCaseRetrievedAssetsDirectoryPath string
+
+	// Iteration is the iteration of the Test Case that is running, counting from 1.
+	Iteration uint
 }
 
 type PipelineVariables struct {
@@ -110,8 +163,14 @@ func NewEmptyPipelineVariables(client *Client) *PipelineVariables {
 	}
 }
 
+// DeepCopy returns a copy of v.  The Values and Context are deep copied.  The Runtime values are copied using
+// PipelineRuntimeValues.Copy(), so the client and the created resources are not.
 func (v *PipelineVariables) DeepCopy() *PipelineVariables {
-	return reprint.This(v).(*PipelineVariables)
+	return &PipelineVariables{
+		Values:  reprint.This(v.Values).(*PipelineVariablesValues),
+		Context: reprint.This(v.Context).(*PipelineVariablesContext),
+		Runtime: v.Runtime.Copy(),
+	}
 }
 
 func (v *PipelineVariables) WithGlobalValues(globalValues map[string]any) *PipelineVariables {
@@ -158,6 +217,15 @@ func (v *PipelineVariables) WithCaseValues(caseValues map[string]any) *PipelineV
 	return v
 }
 
+func (v *PipelineVariables) SetIteration(iteration uint) *PipelineVariables {
+	v.Context.Iteration = iteration
+	return v
+}
+
+func (v *PipelineVariables) ForIteration(iteration uint) *PipelineVariables {
+	return v.SetIteration(iteration)
+}
+
 func (v *PipelineVariables) SetTestCaseRetrievedAssetsDirectoryPath(path string) *PipelineVariables {
 	v.Context.TestCaseRetrievedAssetsDirectoryPath = path
 	return v
@@ -166,3 +234,38 @@ func (v *PipelineVariables) SetTestCaseRetrievedAssetsDirectoryPath(path string)
 func (v *PipelineVariables) AndTestCaseRetrievedAssetsDirectoryAt(path string) *PipelineVariables {
 	return v.SetTestCaseRetrievedAssetsDirectoryPath(path)
 }
+
+// ReplaceValuesAndContextFromJson decodes jsonBytes, which must have the same form as the json delivered to
+// executables on stdin, and replaces the Values and the Context of v with the decoded set.  The Runtime values
+// are managed by jobber, so they are not replaced.
+func (v *PipelineVariables) ReplaceValuesAndContextFromJson(jsonBytes []byte) error {
+	decoded := new(PipelineVariables)
+	if err := json.Unmarshal(jsonBytes, decoded); err != nil {
+		return fmt.Errorf("failed to decode json: %s", err)
+	}
+
+	if decoded.Values == nil {
+		return fmt.Errorf("decoded json does not contain .Values")
+	}
+
+	if decoded.Context == nil {
+		return fmt.Errorf("decoded json does not contain .Context")
+	}
+
+	if decoded.Values.Global == nil {
+		decoded.Values.Global = make(map[string]any)
+	}
+
+	if decoded.Values.Unit == nil {
+		decoded.Values.Unit = make(map[string]any)
+	}
+
+	if decoded.Values.Case == nil {
+		decoded.Values.Case = make(map[string]any)
+	}
+
+	v.Values = decoded.Values
+	v.Context = decoded.Context
+
+	return nil
+}
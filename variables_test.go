@@ -148,3 +148,67 @@ func TestVariables(t *testing.T) {
 	}
 
 }
+
+func TestReplaceValuesAndContextFromJson(t *testing.T) {
+	variables := jobber.NewEmptyPipelineVariables(nil).
+		WithGlobalValues(map[string]any{"TestCaseDurationInSeconds": 600}).
+		RescopedToUnitNamed("unit01").
+		RescopedToCaseNamed("case01").
+		WithCaseValues(map[string]any{"TPS": 100}).
+		AndUsingDefaultNamespaceNamed("default-namespace")
+
+	err := variables.ReplaceValuesAndContextFromJson([]byte(`{
+		"Values": {
+			"Global": {"TestCaseDurationInSeconds": 1200},
+			"Case": {"TPS": 200}
+		},
+		"Context": {
+			"TestUnitName": "unit01",
+			"TestCaseName": "case01",
+			"TestCaseRetrievedAssetsDirectoryPath": "/tmp/case"
+		},
+		"Runtime": {
+			"DefaultNamespace": {
+				"Name": "not-used"
+			}
+		}
+	}`))
+
+	if err != nil {
+		t.Fatalf("did not expect an error, but got error = %s", err)
+	}
+
+	if diff := deep.Equal(variables, &jobber.PipelineVariables{
+		Values: &jobber.PipelineVariablesValues{
+			Global: map[string]any{
+				"TestCaseDurationInSeconds": float64(1200),
+			},
+			Unit: map[string]any{},
+			Case: map[string]any{
+				"TPS": float64(200),
+			},
+		},
+		Context: &jobber.PipelineVariablesContext{
+			TestUnitName:                         "unit01",
+			TestCaseName:                         "case01",
+			TestCaseRetrievedAssetsDirectoryPath: "/tmp/case",
+		},
+		Runtime: &jobber.PipelineRuntimeValues{
+			DefaultNamespace: &jobber.PipelineRuntimeNamespace{
+				Name: "default-namespace",
+			},
+		},
+	}); diff != nil {
+		t.Error(strings.Join(diff, "\t"))
+	}
+
+	for _, invalidJson := range []string{
+		`not json`,
+		`{"Context": {"TestUnitName": "unit01"}}`,
+		`{"Values": {"Global": {}}}`,
+	} {
+		if err := variables.ReplaceValuesAndContextFromJson([]byte(invalidJson)); err == nil {
+			t.Errorf("expected an error on json (%s), but got no error", invalidJson)
+		}
+	}
+}
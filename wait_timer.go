@@ -7,7 +7,7 @@ import (
 )
 
 type Updatable interface {
-	UpdateStatus() error
+	UpdateStatus(ctx context.Context) error
 }
 
 type WaitTimer struct {
@@ -26,14 +26,26 @@ var ErrorTimeExceeded = fmt.Errorf("time limit exceeded")
 
 type WaitTimerExpectationFunction func(objectToTest Updatable) (expectationReached bool, errorOccurred error)
 
-func (t *WaitTimer) TestExpectation(againstObject Updatable, expectationFunc WaitTimerExpectationFunction) (err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), t.MaximumTimeToWait)
+// TestExpectation updates againstObject every ProbeInterval until expectationFunc reports that the expectation
+// has been reached, expectationFunc returns an error, or MaximumTimeToWait passes.  In the last case,
+// ErrorTimeExceeded is returned.  If MaximumTimeToWait is zero, there is no limit.  If ctx is cancelled before
+// then, the ctx error is returned.
+func (t *WaitTimer) TestExpectation(ctx context.Context, againstObject Updatable, expectationFunc WaitTimerExpectationFunction) (err error) {
+	var timerCtx context.Context
+	var cancel context.CancelFunc
+
+	if t.MaximumTimeToWait > 0 {
+		timerCtx, cancel = context.WithTimeout(ctx, t.MaximumTimeToWait)
+	} else {
+		timerCtx, cancel = context.WithCancel(ctx)
+	}
 	defer cancel()
 
 	ticker := time.NewTicker(t.ProbeInterval)
+	defer ticker.Stop()
 
-	if err := againstObject.UpdateStatus(); err != nil {
-		return fmt.Errorf("could not update status: %s", err)
+	if err := againstObject.UpdateStatus(timerCtx); err != nil {
+		return t.explainUpdateFailure(ctx, timerCtx, err)
 	}
 
 	for {
@@ -45,12 +57,26 @@ func (t *WaitTimer) TestExpectation(againstObject Updatable, expectationFunc Wai
 
 		select {
 		case <-ticker.C:
-			if err = againstObject.UpdateStatus(); err != nil {
-				return fmt.Errorf("could not update status: %s", err)
+			if err = againstObject.UpdateStatus(timerCtx); err != nil {
+				return t.explainUpdateFailure(ctx, timerCtx, err)
 			}
 
-		case <-ctx.Done():
+		case <-timerCtx.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			return ErrorTimeExceeded
 		}
 	}
 }
+
+func (t *WaitTimer) explainUpdateFailure(parentCtx context.Context, timerCtx context.Context, err error) error {
+	switch {
+	case parentCtx.Err() != nil:
+		return parentCtx.Err()
+	case timerCtx.Err() != nil:
+		return ErrorTimeExceeded
+	default:
+		return fmt.Errorf("could not update status: %s", err)
+	}
+}
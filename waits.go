@@ -0,0 +1,352 @@
+package jobber
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// These apply to a waits action when no wait timeout or probe interval is configured.
+const (
+	defaultResourceWaitTimeout       = 5 * time.Minute
+	defaultResourceWaitProbeInterval = 2 * time.Second
+)
+
+// ResourceWaitDefinition is the target of a waits action, after template expansion.  Resource identifies the
+// objects that are checked.  The wait ends when every object (of which there must be at least one) meets Condition
+// and JsonPath, whichever are set.  If Count is set, the wait instead ends when exactly Count objects meet them.
+// At least one of Condition, JsonPath and Count must be set.
+type ResourceWaitDefinition struct {
+	Resource  *ResourceWaitTarget    `yaml:"Resource"`
+	Condition *ResourceWaitCondition `yaml:"Condition"`
+	JsonPath  *ResourceWaitJsonPath  `yaml:"JsonPath"`
+	Count     *uint                  `yaml:"Count"`
+
+	jsonPath *jsonpath.JSONPath
+}
+
+// ResourceWaitTarget identifies the objects of a ResourceWaitDefinition, either by Name or by LabelSelector (e.g.,
+// app=nginx,tier!=cache), but not both.  Count can be used only with LabelSelector.  If Namespace is empty and the
+// kind is namespaced, the default Namespace is used.  Namespace is ignored for a cluster-scoped kind.
+type ResourceWaitTarget struct {
+	ApiVersion    string `yaml:"ApiVersion"`
+	Kind          string `yaml:"Kind"`
+	Name          string `yaml:"Name"`
+	Namespace     string `yaml:"Namespace"`
+	LabelSelector string `yaml:"LabelSelector"`
+}
+
+// ResourceWaitCondition is met by an object that has, in .status.conditions, a condition of the given Type with the
+// given Status.  If Status is empty, it is True.
+type ResourceWaitCondition struct {
+	Type   string `yaml:"Type"`
+	Status string `yaml:"Status"`
+}
+
+// ResourceWaitJsonPath is met by an object for which Expression, a kubectl-style JSONPath expression (e.g.,
+// {.status.currentReplicas}), produces Value.  The braces may be omitted.  If Expression produces more than one
+// result, the results are separated by a single space.  A field that does not exist produces the empty string.
+type ResourceWaitJsonPath struct {
+	Expression string `yaml:"Expression"`
+	Value      string `yaml:"Value"`
+}
+
+// ParseResourceWaitDefinition decodes and validates a ResourceWaitDefinition from yamlBytes.
+func ParseResourceWaitDefinition(yamlBytes []byte) (*ResourceWaitDefinition, error) {
+	definition := new(ResourceWaitDefinition)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(yamlBytes))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(definition); err != nil {
+		return nil, fmt.Errorf("failed to decode wait definition: %s", err)
+	}
+
+	target := definition.Resource
+	switch {
+	case target == nil:
+		return nil, fmt.Errorf("wait definition must have a Resource")
+	case target.ApiVersion == "" || target.Kind == "":
+		return nil, fmt.Errorf("wait definition Resource must have an ApiVersion and a Kind")
+	case (target.Name == "") == (target.LabelSelector == ""):
+		return nil, fmt.Errorf("wait definition Resource must have either a Name or a LabelSelector")
+	case definition.Condition == nil && definition.JsonPath == nil && definition.Count == nil:
+		return nil, fmt.Errorf("wait definition must have a Condition, a JsonPath or a Count")
+	case definition.Count != nil && target.LabelSelector == "":
+		return nil, fmt.Errorf("wait definition Count requires a Resource LabelSelector")
+	}
+
+	if target.LabelSelector != "" {
+		if _, err := metav1.ParseToLabelSelector(target.LabelSelector); err != nil {
+			return nil, fmt.Errorf("wait definition Resource LabelSelector (%s) is invalid: %s", target.LabelSelector, err)
+		}
+	}
+
+	if definition.Condition != nil {
+		if definition.Condition.Type == "" {
+			return nil, fmt.Errorf("wait definition Condition must have a Type")
+		}
+		if definition.Condition.Status == "" {
+			definition.Condition.Status = "True"
+		}
+	}
+
+	if definition.JsonPath != nil {
+		expression := strings.TrimSpace(definition.JsonPath.Expression)
+		if expression == "" {
+			return nil, fmt.Errorf("wait definition JsonPath must have an Expression")
+		}
+		if !strings.HasPrefix(expression, "{") {
+			expression = fmt.Sprintf("{%s}", expression)
+		}
+
+		definition.jsonPath = jsonpath.New("JsonPath").AllowMissingKeys(true)
+		if err := definition.jsonPath.Parse(expression); err != nil {
+			return nil, fmt.Errorf("wait definition JsonPath Expression (%s) is invalid: %s", definition.JsonPath.Expression, err)
+		}
+	}
+
+	return definition, nil
+}
+
+// IsSatisfiedBy returns true if objects, which are the objects identified by the Resource of definition, meet the
+// definition.
+func (definition *ResourceWaitDefinition) IsSatisfiedBy(objects []*unstructured.Unstructured) (bool, error) {
+	numberOfObjectsThatMeetDefinition := 0
+
+	for _, object := range objects {
+		meetsDefinition, err := definition.isMetBy(object)
+		if err != nil {
+			return false, err
+		}
+		if meetsDefinition {
+			numberOfObjectsThatMeetDefinition++
+		}
+	}
+
+	if definition.Count != nil {
+		return numberOfObjectsThatMeetDefinition == int(*definition.Count), nil
+	}
+
+	return len(objects) > 0 && numberOfObjectsThatMeetDefinition == len(objects), nil
+}
+
+func (definition *ResourceWaitDefinition) isMetBy(object *unstructured.Unstructured) (bool, error) {
+	if definition.Condition != nil {
+		conditions, _, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+		if err != nil {
+			return false, fmt.Errorf("%s (%s) has malformed .status.conditions: %s", object.GetKind(), object.GetName(), err)
+		}
+
+		conditionIsMet := false
+		for _, condition := range conditions {
+			if conditionMap, isAMap := condition.(map[string]any); isAMap {
+				if fmt.Sprint(conditionMap["type"]) == definition.Condition.Type && fmt.Sprint(conditionMap["status"]) == definition.Condition.Status {
+					conditionIsMet = true
+					break
+				}
+			}
+		}
+
+		if !conditionIsMet {
+			return false, nil
+		}
+	}
+
+	if definition.jsonPath != nil {
+		results, err := definition.jsonPath.FindResults(object.Object)
+		if err != nil {
+			return false, fmt.Errorf("JsonPath (%s) failed for %s (%s): %s", definition.JsonPath.Expression, object.GetKind(), object.GetName(), err)
+		}
+
+		resultStrings := make([]string, 0)
+		for _, resultSet := range results {
+			for _, result := range resultSet {
+				resultStrings = append(resultStrings, fmt.Sprint(result.Interface()))
+			}
+		}
+
+		if strings.Join(resultStrings, " ") != definition.JsonPath.Value {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Description describes what definition waits for (e.g., "Deployment (nginx): condition Available=True").
+func (definition *ResourceWaitDefinition) Description() string {
+	var subject string
+	if definition.Resource.Name != "" {
+		subject = fmt.Sprintf("%s (%s)", definition.Resource.Kind, definition.Resource.Name)
+	} else {
+		subject = fmt.Sprintf("%s matching (%s)", definition.Resource.Kind, definition.Resource.LabelSelector)
+	}
+
+	expectations := make([]string, 0, 2)
+	if definition.Condition != nil {
+		expectations = append(expectations, fmt.Sprintf("condition %s=%s", definition.Condition.Type, definition.Condition.Status))
+	}
+	if definition.JsonPath != nil {
+		expectations = append(expectations, fmt.Sprintf("%s is (%s)", definition.JsonPath.Expression, definition.JsonPath.Value))
+	}
+
+	switch {
+	case definition.Count != nil && len(expectations) > 0:
+		return fmt.Sprintf("%s: exactly %d with %s", subject, *definition.Count, strings.Join(expectations, " and "))
+	case definition.Count != nil:
+		return fmt.Sprintf("%s: exactly %d", subject, *definition.Count)
+	default:
+		return fmt.Sprintf("%s: %s", subject, strings.Join(expectations, " and "))
+	}
+}
+
+// resourceWait is the Updatable for a waits action.  Updating it retrieves the objects identified by the
+// definition.  An object that is named but does not exist yet is not an error; there are simply no objects.
+type resourceWait struct {
+	definition           *ResourceWaitDefinition
+	groupVersionResource schema.GroupVersionResource
+	namespace            string
+	client               *Client
+	objects              []*unstructured.Unstructured
+}
+
+func newResourceWait(definition *ResourceWaitDefinition, defaultNamespaceName string, client *Client) (*resourceWait, error) {
+	gv, err := schema.ParseGroupVersion(definition.Resource.ApiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("wait definition Resource ApiVersion (%s) is invalid: %s", definition.Resource.ApiVersion, err)
+	}
+
+	gvr, isNamespaced, err := client.DetermineResourceAndScopeFromGroupVersionKind(gv.WithKind(definition.Resource.Kind))
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := ""
+	if isNamespaced {
+		if namespace = definition.Resource.Namespace; namespace == "" {
+			namespace = defaultNamespaceName
+		}
+	}
+
+	return &resourceWait{
+		definition:           definition,
+		groupVersionResource: gvr,
+		namespace:            namespace,
+		client:               client,
+	}, nil
+}
+
+func (wait *resourceWait) UpdateStatus(ctx context.Context) error {
+	resourceInterface := wait.client.Dynamic().Resource(wait.groupVersionResource).Namespace(wait.namespace)
+
+	if wait.definition.Resource.Name != "" {
+		object, err := resourceInterface.Get(ctx, wait.definition.Resource.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				wait.objects = nil
+				return nil
+			}
+			return err
+		}
+
+		wait.objects = []*unstructured.Unstructured{object}
+		return nil
+	}
+
+	list, err := resourceInterface.List(ctx, metav1.ListOptions{LabelSelector: wait.definition.Resource.LabelSelector})
+	if err != nil {
+		return err
+	}
+
+	wait.objects = make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		wait.objects[i] = &list.Items[i]
+	}
+
+	return nil
+}
+
+// WaitUntilSatisfied checks the objects every probeInterval until they meet the definition.  If that takes longer
+// than lengthOfTimeToWait, ErrorTimeExceeded is returned.  If ctx is cancelled first, the ctx error is returned.
+func (wait *resourceWait) WaitUntilSatisfied(ctx context.Context, lengthOfTimeToWait time.Duration, probeInterval time.Duration) error {
+	timer := NewWaitTimer(lengthOfTimeToWait, probeInterval)
+
+	return timer.TestExpectation(
+		ctx,
+		wait,
+		func(objectToTest Updatable) (expectationReached bool, errorOccurred error) {
+			return wait.definition.IsSatisfiedBy(wait.objects)
+		},
+	)
+}
+
+// runWait expands the wait definition template, then waits until the definition is met.
+func (action *PipelineAction) runWait(ctx context.Context, pipelineVariables *PipelineVariables, client *Client, eventChannel chan<- *ActionEvent) {
+	templateBuffer, err := action.expandDefinitionTemplate(pipelineVariables)
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: err,
+		}
+		return
+	}
+
+	eventChannel <- &ActionEvent{
+		Type:                   TemplateExpanded,
+		ExpandedTemplateBuffer: templateBuffer,
+	}
+
+	definition, err := ParseResourceWaitDefinition(templateBuffer.Bytes())
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: fmt.Errorf("in (%s): %s", action.ActionFullyQualifiedPath, err),
+		}
+		return
+	}
+
+	wait, err := newResourceWait(definition, pipelineVariables.Runtime.DefaultNamespace.Name, client)
+	if err != nil {
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: err,
+		}
+		return
+	}
+
+	var waitTimeout, probeInterval time.Duration
+	if action.Timeouts != nil {
+		waitTimeout, probeInterval = action.Timeouts.Wait, action.Timeouts.ProbeInterval
+	}
+	waitTimeout = firstNonZeroDuration(waitTimeout, defaultResourceWaitTimeout)
+
+	if err := wait.WaitUntilSatisfied(ctx, waitTimeout, firstNonZeroDuration(probeInterval, defaultResourceWaitProbeInterval)); err != nil {
+		if err == ErrorTimeExceeded {
+			err = fmt.Errorf("%w: wait for (%s) did not end within %s", ErrorTimeExceeded, definition.Description(), waitTimeout)
+		}
+		eventChannel <- &ActionEvent{
+			Type:  AnErrorOccurred,
+			Error: explainedIfActionTimedOut(ctx, err),
+		}
+		return
+	}
+
+	eventChannel <- &ActionEvent{
+		Type:        ResourceWaitCompleted,
+		Description: definition.Description(),
+	}
+
+	eventChannel <- &ActionEvent{
+		Type: ActionCompletedSuccessfully,
+	}
+}
@@ -0,0 +1,176 @@
+package jobber_test
+
+import (
+	"testing"
+
+	"github.com/blorticus-go/jobber"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func hpaWithStatus(name string, currentReplicas int64, conditions ...map[string]any) *unstructured.Unstructured {
+	conditionList := make([]any, len(conditions))
+	for i, c := range conditions {
+		conditionList[i] = c
+	}
+
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "autoscaling/v2",
+		"kind":       "HorizontalPodAutoscaler",
+		"metadata":   map[string]any{"name": name},
+		"status": map[string]any{
+			"currentReplicas": currentReplicas,
+			"conditions":      conditionList,
+		},
+	}}
+}
+
+func TestResourceWaitDefinitions(t *testing.T) {
+	ableToScale := map[string]any{"type": "AbleToScale", "status": "True"}
+	notAbleToScale := map[string]any{"type": "AbleToScale", "status": "False"}
+
+	for _, testCase := range []struct {
+		testName          string
+		definition        string
+		expectAParseError bool
+		objects           []*unstructured.Unstructured
+		expectSatisfied   bool
+	}{
+		{
+			testName: "condition met",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+Condition: {Type: AbleToScale}
+`,
+			objects:         []*unstructured.Unstructured{hpaWithStatus("nginx", 1, ableToScale)},
+			expectSatisfied: true,
+		},
+		{
+			testName: "condition has other status",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+Condition: {Type: AbleToScale}
+`,
+			objects:         []*unstructured.Unstructured{hpaWithStatus("nginx", 1, notAbleToScale)},
+			expectSatisfied: false,
+		},
+		{
+			testName: "named object does not exist yet",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+Condition: {Type: AbleToScale}
+`,
+			objects:         []*unstructured.Unstructured{},
+			expectSatisfied: false,
+		},
+		{
+			testName: "JsonPath without braces",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+JsonPath: {Expression: .status.currentReplicas, Value: "3"}
+`,
+			objects:         []*unstructured.Unstructured{hpaWithStatus("nginx", 3)},
+			expectSatisfied: true,
+		},
+		{
+			testName: "JsonPath with missing field",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+JsonPath: {Expression: "{.status.desiredReplicas}", Value: "3"}
+`,
+			objects:         []*unstructured.Unstructured{hpaWithStatus("nginx", 3)},
+			expectSatisfied: false,
+		},
+		{
+			testName: "every selected object must meet Condition and JsonPath",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, LabelSelector: app=nginx}
+Condition: {Type: AbleToScale, Status: "True"}
+JsonPath: {Expression: "{.status.currentReplicas}", Value: "3"}
+`,
+			objects:         []*unstructured.Unstructured{hpaWithStatus("a", 3, ableToScale), hpaWithStatus("b", 2, ableToScale)},
+			expectSatisfied: false,
+		},
+		{
+			testName: "Count of objects meeting Condition",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, LabelSelector: app=nginx}
+Condition: {Type: AbleToScale}
+Count: 2
+`,
+			objects:         []*unstructured.Unstructured{hpaWithStatus("a", 3, ableToScale), hpaWithStatus("b", 2, notAbleToScale), hpaWithStatus("c", 2, ableToScale)},
+			expectSatisfied: true,
+		},
+		{
+			testName: "Count of zero",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, LabelSelector: app=nginx}
+Count: 0
+`,
+			objects:         []*unstructured.Unstructured{},
+			expectSatisfied: true,
+		},
+		{
+			testName: "both Name and LabelSelector",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx, LabelSelector: app=nginx}
+Condition: {Type: AbleToScale}
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "Count with Name",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+Count: 1
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "nothing to wait for",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "unknown field",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+Condtion: {Type: AbleToScale}
+`,
+			expectAParseError: true,
+		},
+		{
+			testName: "JsonPath that does not parse",
+			definition: `
+Resource: {ApiVersion: autoscaling/v2, Kind: HorizontalPodAutoscaler, Name: nginx}
+JsonPath: {Expression: "{.status[}", Value: "3"}
+`,
+			expectAParseError: true,
+		},
+	} {
+		definition, err := jobber.ParseResourceWaitDefinition([]byte(testCase.definition))
+
+		if testCase.expectAParseError {
+			if err == nil {
+				t.Errorf("[%s] expected a parse error, got no error", testCase.testName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] expected no parse error, got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		isSatisfied, err := definition.IsSatisfiedBy(testCase.objects)
+		if err != nil {
+			t.Errorf("[%s] expected no error from IsSatisfiedBy(), got error = (%s)", testCase.testName, err)
+			continue
+		}
+
+		if isSatisfied != testCase.expectSatisfied {
+			t.Errorf("[%s] expected IsSatisfiedBy() = %t, got %t", testCase.testName, testCase.expectSatisfied, isSatisfied)
+		}
+	}
+}